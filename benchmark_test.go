@@ -0,0 +1,221 @@
+package main
+
+import (
+	"algo/searching/AVLTree"
+	"algo/searching/bTree"
+	"algo/searching/binarySearchTree"
+	"algo/searching/radixTree"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+)
+
+// Benchmarks of every symbol-table backend on the same key streams.
+// Run a subset with e.g.
+//   go test -run '^$' -bench 'Get/AVL/zipf'
+// Sub-benchmarks are named <Op>/<backend>/<stream>/n=<size>.
+
+// The operations benchmarked on every backend
+type benchTable interface {
+	Put(key int, val int)
+	Get(key int) int
+	Rank(key int) int
+	RangeKeys(lo int, hi int) []int
+}
+
+type benchBackend struct {
+	name string
+	new  func() benchTable
+	// Floor results have a different node type per backend
+	floor func(st benchTable, key int)
+	// Delete returns the removed value on some backends and nothing on others
+	delete func(st benchTable, key int)
+	// largest table worth building for a stream, O(n) inserts get slow quickly
+	maxSize func(stream string) int
+}
+
+var benchSizes = []int{1e2, 1e3, 1e4, 1e5, 1e6}
+
+var benchStreams = []string{"random", "sorted", "reversed", "zipf"}
+
+var benchBackends = []benchBackend{
+	{
+		name:    "AVL",
+		new:     func() benchTable { return new(AVLTree.AVL) },
+		floor:   func(st benchTable, key int) { st.(*AVLTree.AVL).Floor(key) },
+		delete:  func(st benchTable, key int) { st.(*AVLTree.AVL).Delete(key) },
+		maxSize: func(stream string) int { return 1e6 },
+	},
+	{
+		name:   "BST",
+		new:    func() benchTable { return new(binarySearchTree.BST) },
+		floor:  func(st benchTable, key int) { st.(*binarySearchTree.BST).Floor(key) },
+		delete: func(st benchTable, key int) { st.(*binarySearchTree.BST).Delete(key) },
+		maxSize: func(stream string) int {
+			// sorted streams degenerate into a linked list
+			if stream == "sorted" || stream == "reversed" {
+				return 1e4
+			}
+			return 1e6
+		},
+	},
+	{
+		name:    "BTree",
+		new:     func() benchTable { return bTree.New(bTree.DefaultDegree) },
+		floor:   func(st benchTable, key int) { st.(*bTree.BTree).Floor(key) },
+		delete:  func(st benchTable, key int) { st.(*bTree.BTree).Delete(key) },
+		maxSize: func(stream string) int { return 1e6 },
+	},
+	{
+		name:    "RadixTree",
+		new:     func() benchTable { return radixTree.NewIntTree() },
+		floor:   func(st benchTable, key int) { st.(*radixTree.IntTree).Floor(key) },
+		delete:  func(st benchTable, key int) { st.(*radixTree.IntTree).Delete(key) },
+		maxSize: func(stream string) int { return 1e6 },
+	},
+	{
+		name:    "SortedArray",
+		new:     func() benchTable { return NewSortedArray() },
+		floor:   func(st benchTable, key int) { st.(*SortedArray).Floor(key) },
+		delete:  func(st benchTable, key int) { st.(*SortedArray).Delete(key) },
+		maxSize: func(stream string) int { return 1e5 },
+	},
+	{
+		name:    "UnsortedArray",
+		new:     func() benchTable { return NewUnsortedArray() },
+		floor:   func(st benchTable, key int) { st.(*UnsortedArray).Floor(key) },
+		delete:  func(st benchTable, key int) { st.(*UnsortedArray).Delete(key) },
+		maxSize: func(stream string) int { return 1e4 },
+	},
+}
+
+// Returns n keys drawn from the named stream.
+// Keys are even so that odd probes miss and exercise Floor.
+func benchKeys(stream string, n int) []int {
+	r := rand.New(rand.NewPCG(1, 2))
+	keys := make([]int, n)
+	switch stream {
+	case "random":
+		for i, v := range r.Perm(n) {
+			keys[i] = 2 * v
+		}
+	case "sorted":
+		for i := range keys {
+			keys[i] = 2 * i
+		}
+	case "reversed":
+		for i := range keys {
+			keys[i] = 2 * (n - 1 - i)
+		}
+	case "zipf":
+		// heavily skewed towards small keys, with many repeats
+		z := rand.NewZipf(r, 1.1, 1, uint64(n-1))
+		for i := range keys {
+			keys[i] = 2 * int(z.Uint64())
+		}
+	}
+	return keys
+}
+
+func benchFill(backend benchBackend, keys []int) benchTable {
+	st := backend.new()
+	for i, key := range keys {
+		st.Put(key, i)
+	}
+	return st
+}
+
+// Runs op for every backend, stream and size that is worth running
+func benchAll(b *testing.B, op func(b *testing.B, backend benchBackend, keys []int)) {
+	for _, backend := range benchBackends {
+		b.Run(backend.name, func(b *testing.B) {
+			for _, stream := range benchStreams {
+				b.Run(stream, func(b *testing.B) {
+					for _, n := range benchSizes {
+						if n > backend.maxSize(stream) {
+							continue
+						}
+						keys := benchKeys(stream, n)
+						b.Run("n="+strconv.Itoa(n), func(b *testing.B) {
+							op(b, backend, keys)
+						})
+					}
+				})
+			}
+		})
+	}
+}
+
+// Average cost of a Put while a table grows from empty to n keys
+func BenchmarkPut(b *testing.B) {
+	benchAll(b, func(b *testing.B, backend benchBackend, keys []int) {
+		st := backend.new()
+		for i := 0; i < b.N; i++ {
+			if i%len(keys) == 0 && i > 0 {
+				b.StopTimer()
+				st = backend.new()
+				b.StartTimer()
+			}
+			st.Put(keys[i%len(keys)], i)
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	benchAll(b, func(b *testing.B, backend benchBackend, keys []int) {
+		st := benchFill(backend, keys)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			st.Get(keys[i%len(keys)])
+		}
+	})
+}
+
+// Average cost of a Delete while a full table is emptied
+func BenchmarkDelete(b *testing.B) {
+	benchAll(b, func(b *testing.B, backend benchBackend, keys []int) {
+		st := benchFill(backend, keys)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if i%len(keys) == 0 && i > 0 {
+				b.StopTimer()
+				st = benchFill(backend, keys)
+				b.StartTimer()
+			}
+			backend.delete(st, keys[i%len(keys)])
+		}
+	})
+}
+
+func BenchmarkFloor(b *testing.B) {
+	benchAll(b, func(b *testing.B, backend benchBackend, keys []int) {
+		st := benchFill(backend, keys)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			// odd probes are never in the table
+			backend.floor(st, keys[i%len(keys)]+1)
+		}
+	})
+}
+
+func BenchmarkRank(b *testing.B) {
+	benchAll(b, func(b *testing.B, backend benchBackend, keys []int) {
+		st := benchFill(backend, keys)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			st.Rank(keys[i%len(keys)] + 1)
+		}
+	})
+}
+
+// Ranges spanning up to 100 keys
+func BenchmarkRangeKeys(b *testing.B) {
+	benchAll(b, func(b *testing.B, backend benchBackend, keys []int) {
+		st := benchFill(backend, keys)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			lo := keys[i%len(keys)]
+			st.RangeKeys(lo, lo+200)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"algo/searching/conformance"
+	"testing"
+)
+
+func TestSortedArrayConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapt[*Node](NewSortedArray, (*SortedArray).check))
+}
+
+func TestUnsortedArrayConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapt[*Node](NewUnsortedArray, (*UnsortedArray).check))
+}
+
+func TestSortedArrayReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Node](func() *SortedArray { return NewSortedArrayWithComparator(conformance.Descending) }, (*SortedArray).check))
+}
+
+func TestUnsortedArrayReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Node](func() *UnsortedArray { return NewUnsortedArrayWithComparator(conformance.Descending) }, (*UnsortedArray).check))
+}
+
+func FuzzSortedArray(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Node](NewSortedArray, (*SortedArray).check))
+}
+
+func FuzzUnsortedArray(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Node](NewUnsortedArray, (*UnsortedArray).check))
+}
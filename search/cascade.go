@@ -0,0 +1,95 @@
+package search
+
+import (
+	"cmp"
+	"slices"
+)
+
+// One list of a Cascade, merged with every other element of the next
+// list's merged form
+type cascadeLevel[T cmp.Ordered] struct {
+	merged []T
+	// own[p] is the number of elements of the original list in merged[:p]
+	own []int
+	// bridge[p] is one past the next level's index of the last element of
+	// merged[:p] that came from the next level, 0 if there is none
+	bridge []int
+}
+
+// Fractional cascading over k sorted lists: LowerBounds finds the lower
+// bound of a value in every list with one binary search and O(1) work per
+// further list, O(log n + k) instead of the O(k log n) of k binary searches.
+// Every list is merged with every other element of the next (already
+// merged) list, which at most doubles the total size, and each merged
+// element records where it falls in its own list and in the next one.
+// https://en.wikipedia.org/wiki/Fractional_cascading
+type Cascade[T cmp.Ordered] struct {
+	levels []cascadeLevel[T]
+}
+
+// Builds a cascade over copies of the lists, each sorted in ascending order
+func NewCascade[T cmp.Ordered](lists ...[]T) *Cascade[T] {
+	c := &Cascade[T]{levels: make([]cascadeLevel[T], len(lists))}
+	for i := len(lists) - 1; i >= 0; i-- {
+		list := lists[i]
+		if !slices.IsSorted(list) {
+			panic("search: NewCascade needs sorted lists")
+		}
+		var promoted []T
+		if i+1 < len(lists) {
+			// the odd indices, so that the element after a promoted one is never promoted
+			next := c.levels[i+1].merged
+			for j := 1; j < len(next); j += 2 {
+				promoted = append(promoted, next[j])
+			}
+		}
+		level := cascadeLevel[T]{
+			merged: make([]T, 0, len(list)+len(promoted)),
+			own:    make([]int, 1, len(list)+len(promoted)+1),
+			bridge: make([]int, 1, len(list)+len(promoted)+1),
+		}
+		a, b := 0, 0
+		for a < len(list) || b < len(promoted) {
+			ownCount, bridge := level.own[len(level.own)-1], level.bridge[len(level.bridge)-1]
+			if b == len(promoted) || (a < len(list) && list[a] <= promoted[b]) {
+				level.merged = append(level.merged, list[a])
+				ownCount++
+				a++
+			} else {
+				level.merged = append(level.merged, promoted[b])
+				// promoted[b] is next[2b+1]
+				bridge = 2*b + 2
+				b++
+			}
+			level.own = append(level.own, ownCount)
+			level.bridge = append(level.bridge, bridge)
+		}
+		c.levels[i] = level
+	}
+	return c
+}
+
+// Returns the lower bound of x in every list, in order: the index of the
+// first element not less than x, the list's length if there is none
+func (c *Cascade[T]) LowerBounds(x T) []int {
+	res := make([]int, len(c.levels))
+	if len(c.levels) == 0 {
+		return res
+	}
+	p := LowerBound(c.levels[0].merged, x)
+	for i := range c.levels {
+		level := &c.levels[i]
+		res[i] = level.own[p]
+		if i+1 == len(c.levels) {
+			break
+		}
+		// everything before the bridge is less than x, and the element two
+		// past it was promoted at or after p, so it is not
+		next := c.levels[i+1].merged
+		p = level.bridge[p]
+		if p < len(next) && next[p] < x {
+			p++
+		}
+	}
+	return res
+}
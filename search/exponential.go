@@ -0,0 +1,44 @@
+package search
+
+import (
+	"cmp"
+)
+
+// Returns LowerBound(a, x) by galloping: probe a[0], a[1], a[3], a[7], ...
+// until an element not less than x turns up, then binary search the last
+// gap. O(log i) comparisons where i is the result, so it beats plain binary
+// search when the target is near the front, as when merging runs.
+// https://en.wikipedia.org/wiki/Exponential_search
+func Exponential[T cmp.Ordered](a []T, x T) int {
+	return ExponentialFunc(a, x, cmp.Compare[T])
+}
+
+// Returns LowerBoundFunc(a, x, cmp) by galloping from the front of a
+func ExponentialFunc[E any, T any](a []E, x T, cmp func(e E, x T) int) int {
+	return GallopFunc(a, x, 0, cmp)
+}
+
+// Returns LowerBoundFunc(a, x, cmp) by galloping from index hint, in either
+// direction: O(log d) comparisons where d is the distance from hint to the
+// result. hint is clamped to [0, len(a)].
+func GallopFunc[E any, T any](a []E, x T, hint int, cmp func(e E, x T) int) int {
+	hint = max(0, min(hint, len(a)))
+	if hint < len(a) && cmp(a[hint], x) < 0 {
+		// the result is after hint: a[hint+step/2] < x is known
+		lo, step := hint+1, 1
+		for lo+step-1 < len(a) && cmp(a[lo+step-1], x) < 0 {
+			lo += step
+			step *= 2
+		}
+		hi := min(lo+step-1, len(a))
+		return lo + LowerBoundFunc(a[lo:hi], x, cmp)
+	}
+	// the result is at or before hint: a[hint] >= x, or hint is len(a)
+	hi, step := hint, 1
+	for hi-step >= 0 && cmp(a[hi-step], x) >= 0 {
+		hi -= step
+		step *= 2
+	}
+	lo := max(hi-step+1, 0)
+	return lo + LowerBoundFunc(a[lo:hi], x, cmp)
+}
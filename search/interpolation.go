@@ -0,0 +1,45 @@
+package search
+
+// The types Interpolation can compute positions with
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Returns LowerBound(a, x) by interpolation search: guess the position of x
+// from its value relative to the ends of the range, as one looks up a word
+// in a dictionary. O(log log n) probes on average when the values are
+// uniformly distributed. A guess that fails to halve the range is followed
+// by a bisection step, which keeps the worst case at O(log n) probes.
+// https://en.wikipedia.org/wiki/Interpolation_search
+func Interpolation[T Integer](a []T, x T) int {
+	// the result is in [lo, hi]: a[lo-1] < x and a[hi] >= x where they exist
+	lo, hi := 0, len(a)
+	bisect := false
+	for lo < hi {
+		if x <= a[lo] {
+			return lo
+		}
+		if x > a[hi-1] {
+			return hi
+		}
+		// now a[lo] < x <= a[hi-1], so hi-1 > lo
+		var pos int
+		if bisect {
+			pos = lo + (hi-lo)/2
+		} else {
+			// floats so that differences of large values cannot overflow
+			frac := (float64(x) - float64(a[lo])) / (float64(a[hi-1]) - float64(a[lo]))
+			pos = lo + int(frac*float64(hi-1-lo))
+			pos = max(lo, min(pos, hi-1))
+		}
+		before := hi - lo
+		if a[pos] < x {
+			lo = pos + 1
+		} else {
+			hi = pos
+		}
+		bisect = 2*(hi-lo) > before
+	}
+	return lo
+}
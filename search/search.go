@@ -0,0 +1,93 @@
+// Binary search and its relatives over sorted slices and monotone predicates.
+// Every search returns an insertion point rather than a found flag: the
+// index where the target is, or would go to keep the slice sorted.
+// LowerBound and UpperBound follow C++'s std::lower_bound and upper_bound;
+// the Func forms take a comparison returning a negative number, zero or a
+// positive number like cmp.Compare, so slices of records can be searched
+// by a field.
+// https://en.cppreference.com/w/cpp/algorithm/lower_bound
+package search
+
+import (
+	"cmp"
+)
+
+// Returns the smallest index i in [lo, hi) such that pred(i) is true, or hi
+// if there is none. pred must be monotone: false up to some index, then
+// true. Calls pred O(log(hi-lo)) times.
+func FirstTrue(lo int, hi int, pred func(i int) bool) int {
+	for lo < hi {
+		// lo + (hi-lo)/2 cannot overflow like (lo+hi)/2 can
+		mid := lo + (hi-lo)/2
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// Returns the smallest x in [lo, hi] such that pred(x) is true, to within
+// eps, or hi if pred(hi) is false. pred must be monotone: false up to some
+// point, then true. An eps of 0 narrows down to adjacent float64 values.
+func FirstTrueFloat(lo float64, hi float64, eps float64, pred func(x float64) bool) float64 {
+	if pred(lo) {
+		return lo
+	}
+	if !pred(hi) {
+		return hi
+	}
+	// invariant: pred(lo) is false and pred(hi) is true
+	for hi-lo > eps {
+		mid := lo + (hi-lo)/2
+		if mid <= lo || mid >= hi {
+			break
+		}
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
+
+// Returns the index of the first element of a not less than x,
+// len(a) if there is none. a must be sorted in ascending order.
+func LowerBound[T cmp.Ordered](a []T, x T) int {
+	return LowerBoundFunc(a, x, cmp.Compare[T])
+}
+
+// Returns the index of the first element e of a with cmp(e, x) >= 0,
+// len(a) if there is none. a must be sorted according to cmp.
+func LowerBoundFunc[E any, T any](a []E, x T, cmp func(e E, x T) int) int {
+	return FirstTrue(0, len(a), func(i int) bool { return cmp(a[i], x) >= 0 })
+}
+
+// Returns the index of the first element of a greater than x,
+// len(a) if there is none. a must be sorted in ascending order.
+func UpperBound[T cmp.Ordered](a []T, x T) int {
+	return UpperBoundFunc(a, x, cmp.Compare[T])
+}
+
+// Returns the index of the first element e of a with cmp(e, x) > 0,
+// len(a) if there is none. a must be sorted according to cmp.
+func UpperBoundFunc[E any, T any](a []E, x T, cmp func(e E, x T) int) int {
+	return FirstTrue(0, len(a), func(i int) bool { return cmp(a[i], x) > 0 })
+}
+
+// Returns the range a[lo:hi] of the elements equal to x, empty at the
+// insertion point of x if there are none. a must be sorted in ascending order.
+func EqualRange[T cmp.Ordered](a []T, x T) (lo int, hi int) {
+	return EqualRangeFunc(a, x, cmp.Compare[T])
+}
+
+// Returns the range a[lo:hi] of the elements e with cmp(e, x) == 0.
+// a must be sorted according to cmp.
+func EqualRangeFunc[E any, T any](a []E, x T, cmp func(e E, x T) int) (lo int, hi int) {
+	lo = LowerBoundFunc(a, x, cmp)
+	// the upper bound is at or after the lower bound
+	hi = lo + UpperBoundFunc(a[lo:], x, cmp)
+	return lo, hi
+}
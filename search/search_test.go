@@ -0,0 +1,192 @@
+package search
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// Calls f with every sorted slice of length up to maxLen over [0, values)
+func eachSorted(maxLen int, values int, f func(a []int)) {
+	var rec func(a []int)
+	rec = func(a []int) {
+		f(a)
+		if len(a) == maxLen {
+			return
+		}
+		from := 0
+		if len(a) > 0 {
+			from = a[len(a)-1]
+		}
+		for v := from; v < values; v++ {
+			rec(append(a, v))
+		}
+	}
+	rec(nil)
+}
+
+// every sorted slice of length up to 7 over 4 values, every target from
+// below the smallest to above the largest value
+func Test1(t *testing.T) {
+	eachSorted(7, 4, func(a []int) {
+		for x := -1; x <= 4; x++ {
+			lower, upper := 0, 0
+			for _, v := range a {
+				if v < x {
+					lower++
+				}
+				if v <= x {
+					upper++
+				}
+			}
+			if LowerBound(a, x) != lower || UpperBound(a, x) != upper {
+				t.Fatalf("%v, %d: LowerBound/UpperBound = %d %d, want %d %d", a, x, LowerBound(a, x), UpperBound(a, x), lower, upper)
+			}
+			if lo, hi := EqualRange(a, x); lo != lower || hi != upper {
+				t.Fatalf("%v, %d: EqualRange = %d %d", a, x, lo, hi)
+			}
+			if Exponential(a, x) != lower || Interpolation(a, x) != lower {
+				t.Fatalf("%v, %d: Exponential/Interpolation = %d %d, want %d", a, x, Exponential(a, x), Interpolation(a, x), lower)
+			}
+			for hint := -1; hint <= len(a)+1; hint++ {
+				if got := GallopFunc(a, x, hint, cmp.Compare[int]); got != lower {
+					t.Fatalf("%v, %d: GallopFunc from %d = %d, want %d", a, x, hint, got, lower)
+				}
+			}
+		}
+	})
+}
+
+// every threshold of every range up to length 8
+func Test2(t *testing.T) {
+	for lo := -3; lo <= 3; lo++ {
+		for hi := lo; hi <= lo+8; hi++ {
+			for threshold := lo - 1; threshold <= hi+1; threshold++ {
+				calls := 0
+				got := FirstTrue(lo, hi, func(i int) bool {
+					calls++
+					if i < lo || i >= hi {
+						t.Fatalf("FirstTrue(%d, %d) probed %d", lo, hi, i)
+					}
+					return i >= threshold
+				})
+				if want := max(lo, min(threshold, hi)); got != want {
+					t.Fatalf("FirstTrue(%d, %d) with threshold %d = %d, want %d", lo, hi, threshold, got, want)
+				}
+				if calls > 4 {
+					t.Fatalf("FirstTrue(%d, %d) made %d calls", lo, hi, calls)
+				}
+			}
+		}
+	}
+}
+
+func Test3(t *testing.T) {
+	sqrt2 := FirstTrueFloat(0, 2, 1e-12, func(x float64) bool { return x*x >= 2 })
+	if math.Abs(sqrt2-math.Sqrt2) > 1e-12 {
+		t.Error("FirstTrueFloat Wrong", sqrt2)
+	}
+	// eps 0 lands on the exact float
+	if x := FirstTrueFloat(-1e300, 1e300, 0, func(x float64) bool { return x >= 0.1 }); x != 0.1 {
+		t.Error("FirstTrueFloat Exact Wrong", x)
+	}
+	if FirstTrueFloat(1, 2, 0, func(x float64) bool { return true }) != 1 || FirstTrueFloat(1, 2, 0, func(x float64) bool { return false }) != 2 {
+		t.Error("FirstTrueFloat Ends Wrong")
+	}
+}
+
+// searching records by a field with the Func forms
+func Test4(t *testing.T) {
+	type entry struct {
+		key  int
+		name string
+	}
+	a := []entry{{1, "a"}, {3, "b"}, {3, "c"}, {8, "d"}}
+	byKey := func(e entry, key int) int { return cmp.Compare(e.key, key) }
+	if lo, hi := EqualRangeFunc(a, 3, byKey); lo != 1 || hi != 3 {
+		t.Error("EqualRangeFunc Wrong")
+	}
+	if LowerBoundFunc(a, 4, byKey) != 3 || UpperBoundFunc(a, 8, byKey) != 4 || ExponentialFunc(a, 0, byKey) != 0 {
+		t.Error("Func Bounds Wrong")
+	}
+}
+
+// interpolation on large, skewed and extreme values
+func Test5(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 1))
+	uniform := make([]int, 10000)
+	skewed := make([]int, 10000)
+	for i := range uniform {
+		uniform[i] = r.IntN(1 << 40)
+		skewed[i] = i * i * i
+	}
+	slices.Sort(uniform)
+	extreme := []int64{math.MinInt64, -1, 0, 1, math.MaxInt64}
+	for i := 0; i < 1000; i++ {
+		x := r.IntN(1<<40 + 2)
+		if Interpolation(uniform, x) != LowerBound(uniform, x) {
+			t.Fatal("Interpolation Wrong on Uniform")
+		}
+		x = r.IntN(10000 * 10000 * 10000)
+		if Interpolation(skewed, x) != LowerBound(skewed, x) {
+			t.Fatal("Interpolation Wrong on Skewed")
+		}
+	}
+	for _, x := range extreme {
+		if Interpolation(extreme, x) != LowerBound(extreme, x) {
+			t.Fatal("Interpolation Wrong on Extremes")
+		}
+	}
+	if Interpolation([]uint8{0, 255}, 128) != 1 {
+		t.Error("Interpolation Wrong on Bytes")
+	}
+}
+
+// every combination of up to 3 sorted lists of length up to 3 over 4
+// values, against one binary search per list
+func Test6(t *testing.T) {
+	var lists [][]int
+	eachSorted(3, 4, func(a []int) { lists = append(lists, slices.Clone(a)) })
+	check := func(ls ...[]int) {
+		c := NewCascade(ls...)
+		for x := -1; x <= 4; x++ {
+			got := c.LowerBounds(x)
+			for i, l := range ls {
+				if got[i] != LowerBound(l, x) {
+					t.Fatalf("%v, %d: LowerBounds = %v", ls, x, got)
+				}
+			}
+		}
+	}
+	check()
+	for _, a := range lists {
+		for _, b := range lists {
+			check(a, b)
+			for _, c := range lists {
+				check(a, b, c)
+			}
+		}
+	}
+	// longer random lists
+	r := rand.New(rand.NewPCG(1, 1))
+	ls := make([][]int, 10)
+	for i := range ls {
+		ls[i] = make([]int, r.IntN(50))
+		for j := range ls[i] {
+			ls[i][j] = r.IntN(100)
+		}
+		slices.Sort(ls[i])
+	}
+	check(ls...)
+}
+
+func Test7(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Unsorted List Accepted")
+		}
+	}()
+	NewCascade([]int{1, 2}, []int{3, 1})
+}
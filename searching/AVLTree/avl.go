@@ -3,7 +3,13 @@
 // https://algs4.cs.princeton.edu/code/javadoc/edu/princeton/cs/algs4/AVL.html
 package AVLTree
 
-import "algo/utils"
+import (
+	"fmt"
+
+	"algo/searching/observer"
+	"algo/searching/traversal"
+	"algo/utils"
+)
 
 const KeyNotExist = "Key Not Exist"
 
@@ -15,6 +21,26 @@ type Node struct {
 	left, right *Node
 }
 
+// Returns the key stored in the node
+func (n *Node) Key() int {
+	return n.key
+}
+
+// Returns the value stored in the node
+func (n *Node) Val() int {
+	return n.val
+}
+
+// Returns the left child, nil if there is none
+func (n *Node) Left() *Node {
+	return n.left
+}
+
+// Returns the right child, nil if there is none
+func (n *Node) Right() *Node {
+	return n.right
+}
+
 type BinaryTree interface {
 	IsEmpty() bool
 	Size() int
@@ -23,7 +49,11 @@ type BinaryTree interface {
 	Put(key int, val int)
 	DeleteMin()
 	DeleteMax()
-	Delete(key int)
+	Delete(key int) (int, bool)
+	Remove(key int) (int, bool)
+	PopMin() (int, int, bool)
+	PopMax() (int, int, bool)
+	DeleteRange(lo int, hi int) int // Removes every key in [lo, hi] and returns how many there were
 	Min() int
 	Max() int
 	Floor() int
@@ -34,11 +64,57 @@ type BinaryTree interface {
 	RangeKeys(lo int, hi int) // Returns all keys in the symbol table in the given range.
 	RangeSize(lo int, hi int) // Returns the number of keys in the symbol table in the given range.
 	LevelOrder()
+	Levels()
+	PreOrder()
+	InOrder()
+	PostOrder()
+	ZigZag()
+	MorrisInOrder()
 }
 
 // The struct represents an ordered symbol table of int key-value pairs.
 type AVL struct {
 	root *Node
+	// rotations performed so far, reported by Stats
+	leftRotations, rightRotations int
+	// optional instrumentation, nil when unused
+	observer observer.Observer
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+}
+
+// Installs an observer that is notified of comparisons, visits,
+// rotations and allocations; pass nil to remove it
+func (t *AVL) SetObserver(o observer.Observer) {
+	t.observer = o
+}
+
+// Returns an empty tree ordered by compare
+func NewWithComparator(compare utils.Comparator) *AVL {
+	return &AVL{comparator: compare}
+}
+
+// Notifies the observer, then compares the keys in the tree's order
+func (t *AVL) compare(a int, b int) int {
+	if t.observer != nil {
+		t.observer.OnCompare(a, b)
+	}
+	return t.comparator.Compare(a, b)
+}
+
+// Reports that the node is looked at
+func (t *AVL) visit(node *Node) {
+	if t.observer != nil {
+		t.observer.OnVisit(node.key)
+	}
+}
+
+// Allocates a node holding a single key-value pair
+func (t *AVL) newNode(key int, val int) *Node {
+	if t.observer != nil {
+		t.observer.OnAlloc(key)
+	}
+	return &Node{key: key, val: val, size: 1, height: 0}
 }
 
 // have such a helper function to avoid visiting nil node
@@ -69,14 +145,25 @@ func (t *AVL) Height() int {
 	return t.height(t.root)
 }
 
+// Returns the shape of the tree together with the number of rotations
+// performed by Put and Delete since the tree was created
+func (t *AVL) Stats() traversal.Stats {
+	s := traversal.ShapeOf(t.root)
+	s.LeftRotations = t.leftRotations
+	s.RightRotations = t.rightRotations
+	return s
+}
+
 // Returns the node by key
 func (t *AVL) get(n *Node, key int) *Node {
 	if n == nil {
 		return nil
 	} else {
-		if key == n.key {
+		t.visit(n)
+		cmp := t.compare(key, n.key)
+		if cmp == 0 {
 			return n
-		} else if key < n.key {
+		} else if cmp < 0 {
 			return t.get(n.left, key)
 		} else {
 			return t.get(n.right, key)
@@ -119,6 +206,10 @@ func (t *AVL) delta(node *Node) int {
 
 // Rotates the given subtree to the left.
 func (t *AVL) rotateLeft(node *Node) *Node {
+	t.leftRotations++
+	if t.observer != nil {
+		t.observer.OnRotate(observer.Left, node.key)
+	}
 	newHead := node.right
 	node.right = newHead.left
 	newHead.left = node
@@ -134,6 +225,10 @@ func (t *AVL) rotateLeft(node *Node) *Node {
 
 // Rotates the given subtree to the right.
 func (t *AVL) rotateRight(node *Node) *Node {
+	t.rightRotations++
+	if t.observer != nil {
+		t.observer.OnRotate(observer.Right, node.key)
+	}
 	newHead := node.left
 	node.left = newHead.right
 	newHead.right = node
@@ -169,14 +264,16 @@ func (t *AVL) balance(node *Node) *Node {
 
 func (t *AVL) put(node *Node, key int, val int) *Node {
 	if node == nil {
-		return &Node{key, val, 1, 0, nil, nil}
+		return t.newNode(key, val)
 	} else {
-		if key < node.key {
+		t.visit(node)
+		cmp := t.compare(key, node.key)
+		if cmp < 0 {
 			node.left = t.put(node.left, key, val)
 			// have such a t.size helper function to avoid visiting nil node
 			node.size = 1 + t.size(node.left) + t.size(node.right)
 			node.height = 1 + utils.MaxOf(t.height(node.left), t.height(node.right))
-		} else if key == node.key {
+		} else if cmp == 0 {
 			node.key = key
 			node.val = val
 		} else {
@@ -259,34 +356,173 @@ func (t *AVL) findMax(node *Node) *Node {
 	return node
 }
 
-func (t *AVL) delete(node *Node, key int) *Node {
+// Removes the node from its subtree and returns the new root of the subtree
+func (t *AVL) deleteNode(node *Node) *Node {
+	if node.left == nil {
+		return node.right
+	} else if node.right == nil {
+		return node.left
+	}
+	var minNode *Node = t.findMin(node.right)
+	node.key = minNode.key
+	node.val = minNode.val
+	node.right = t.deleteMin(node.right) // dont forget node.right
+	node.height = 1 + utils.MaxOf(t.height(node.left), t.height(node.right))
+	node.size = 1 + t.size(node.left) + t.size(node.right) // dont forget update
+	return t.balance(node)
+}
+
+func (t *AVL) compute(node *Node, key int, f func(old int, ok bool) (int, bool)) *Node {
 	if node == nil {
+		if val, keep := f(0, false); keep {
+			return t.newNode(key, val)
+		}
 		return nil
-	} else if node.key == key {
-		if node.left == nil {
-			return node.right
-		} else if node.right == nil {
-			return node.left
-		} else {
-			var minNode *Node = t.findMin(node.right)
-			node.key = minNode.key
-			node.val = minNode.val
-			node.right = t.deleteMin(node.right) // dont forget node.right
-			node.height = 1 + utils.MaxOf(t.height(node.left), t.height(node.right))
-			node.size = 1 + t.size(node.left) + t.size(node.right) // dont forget update
-			return t.balance(node)
+	}
+	t.visit(node)
+	cmp := t.compare(key, node.key)
+	if cmp == 0 {
+		val, keep := f(node.val, true)
+		if !keep {
+			return t.deleteNode(node)
 		}
-	} else if key < node.key {
-		node.left = t.delete(node.left, key)
+		node.val = val
+		return node
+	} else if cmp < 0 {
+		node.left = t.compute(node.left, key, f)
 	} else {
-		node.right = t.delete(node.right, key)
+		node.right = t.compute(node.right, key, f)
 	}
+	node.height = 1 + utils.MaxOf(t.height(node.left), t.height(node.right))
 	node.size = 1 + t.size(node.left) + t.size(node.right) // dont forget update
 	return t.balance(node)
 }
 
-func (t *AVL) Delete(key int) {
-	t.root = t.balance(t.delete(t.root, key))
+// Calls f with the value of key and whether the key exists, then stores
+// the value f returns, or removes the key if f says not to keep it.
+// The key is looked up once, so a counter is updated in a single descent.
+func (t *AVL) Compute(key int, f func(old int, ok bool) (val int, keep bool)) {
+	t.root = t.compute(t.root, key, f)
+}
+
+// Returns the value of key and true if it exists; otherwise puts val and
+// returns it with false
+func (t *AVL) GetOrPut(key int, val int) (actual int, loaded bool) {
+	actual = val
+	t.Compute(key, func(old int, ok bool) (int, bool) {
+		if ok {
+			actual, loaded = old, true
+			return old, true
+		}
+		return val, true
+	})
+	return actual, loaded
+}
+
+// Puts val and returns the previous value of key and whether there was one
+func (t *AVL) Swap(key int, val int) (previous int, loaded bool) {
+	t.Compute(key, func(old int, ok bool) (int, bool) {
+		previous, loaded = old, ok
+		return val, true
+	})
+	return previous, loaded
+}
+
+// Removes the key and associated value from the symbol table, if present.
+// Returns the removed value and whether the key was there.
+func (t *AVL) Delete(key int) (val int, found bool) {
+	t.Compute(key, func(old int, ok bool) (int, bool) {
+		val, found = old, ok
+		return 0, false
+	})
+	return val, found
+}
+
+// Same as Delete, under the name every backend shares
+func (t *AVL) Remove(key int) (val int, ok bool) {
+	return t.Delete(key)
+}
+
+// Removes the smallest key and returns it with its value; false if the tree is empty
+func (t *AVL) PopMin() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	minNode := t.findMin(t.root)
+	key, val = minNode.key, minNode.val
+	t.root = t.balance(t.deleteMin(t.root))
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the tree is empty
+func (t *AVL) PopMax() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	maxNode := t.findMax(t.root)
+	key, val = maxNode.key, maxNode.val
+	t.root = t.balance(t.deleteMax(t.root))
+	return key, val, true
+}
+
+// Joins left, node and right into one AVL tree, where every key of left is
+// less than node's key and every key of right greater. O(|height difference|):
+// the shorter tree hangs off the spine of the taller one at a node of about
+// its height, and the rotations on the way back up restore the balance.
+func (t *AVL) join(left *Node, node *Node, right *Node) *Node {
+	if t.height(left) > t.height(right)+1 {
+		left.right = t.join(left.right, node, right)
+		left.height = 1 + utils.MaxOf(t.height(left.left), t.height(left.right))
+		left.size = 1 + t.size(left.left) + t.size(left.right)
+		return t.balance(left)
+	}
+	if t.height(right) > t.height(left)+1 {
+		right.left = t.join(left, node, right.left)
+		right.height = 1 + utils.MaxOf(t.height(right.left), t.height(right.right))
+		right.size = 1 + t.size(right.left) + t.size(right.right)
+		return t.balance(right)
+	}
+	node.left, node.right = left, right
+	node.height = 1 + utils.MaxOf(t.height(left), t.height(right))
+	node.size = 1 + t.size(left) + t.size(right)
+	return node
+}
+
+// Splits the subtree into the keys before key and the rest, where before
+// means less than key, or less than or equal to it if orEqual is set.
+// O(log n), joining the pieces cut off along the search path.
+func (t *AVL) split(node *Node, key int, orEqual bool) (*Node, *Node) {
+	if node == nil {
+		return nil, nil
+	}
+	t.visit(node)
+	cmp := t.compare(node.key, key)
+	if cmp < 0 || (orEqual && cmp == 0) {
+		left, right := t.split(node.right, key, orEqual)
+		return t.join(node.left, node, left), right
+	}
+	left, right := t.split(node.left, key, orEqual)
+	return left, t.join(right, node, node.right)
+}
+
+// Removes every key in [lo, hi] and returns how many there were. The tree
+// is split around the range and the outer parts joined again, which takes
+// O(log n) however many keys go.
+func (t *AVL) DeleteRange(lo int, hi int) int {
+	if t.compare(lo, hi) > 0 {
+		return 0
+	}
+	left, rest := t.split(t.root, lo, false)
+	middle, right := t.split(rest, hi, true)
+	if right == nil {
+		t.root = left
+	} else {
+		// the smallest key of right becomes the node joining the two sides
+		minNode := t.findMin(right)
+		right = t.balance(t.deleteMin(right))
+		t.root = t.join(left, minNode, right)
+	}
+	return t.size(middle)
 }
 
 func (t *AVL) Min() (key int, val int) {
@@ -305,9 +541,11 @@ func (t *AVL) floor(node *Node, key int) *Node {
 		return node
 	}
 
-	if node.key == key {
+	t.visit(node)
+	cmp := t.compare(key, node.key)
+	if cmp == 0 {
 		return node
-	} else if node.key < key {
+	} else if cmp > 0 {
 		// pay attention to this part!!
 		// if node.right != nil {return t.floor(node.right, key)}
 		//  maybe the right tree are all ndoes > key
@@ -333,9 +571,11 @@ func (t *AVL) ceiling(node *Node, key int) *Node {
 		return node
 	}
 
-	if node.key == key {
+	t.visit(node)
+	cmp := t.compare(key, node.key)
+	if cmp == 0 {
 		return node
-	} else if node.key > key {
+	} else if cmp < 0 {
 		// pay attention to this part!!
 		// if node.right != nil {return t.floor(node.right, key)}
 		//  maybe the right tree are all ndoes > key
@@ -359,6 +599,7 @@ func (t *AVL) selectHelper(node *Node, k int) *Node {
 	if node == nil {
 		return node
 	}
+	t.visit(node)
 	if t.size(node.left) == k { // say k = 0, should return the smallest
 		return node
 	} else if t.size(node.left) < k {
@@ -381,9 +622,11 @@ func (t *AVL) rank(node *Node, key int) int {
 	if node == nil {
 		return 0
 	}
-	if node.key < key {
+	t.visit(node)
+	cmp := t.compare(key, node.key)
+	if cmp > 0 {
 		return t.size(node.left) + 1 + t.rank(node.right, key)
-	} else if node.key == key {
+	} else if cmp == 0 {
 		return t.size(node.left)
 	} else {
 		return t.rank(node.left, key)
@@ -416,9 +659,10 @@ func (t *AVL) rangekeys(node *Node, lo int, hi int, res *[]int) {
 	if node == nil {
 		return
 	}
-	if node.key < lo {
+	t.visit(node)
+	if t.compare(lo, node.key) > 0 {
 		t.rangekeys(node.right, lo, hi, res)
-	} else if node.key > hi {
+	} else if t.compare(hi, node.key) < 0 {
 		t.rangekeys(node.left, lo, hi, res)
 	} else {
 		t.rangekeys(node.left, lo, hi, res)
@@ -441,22 +685,134 @@ func (t *AVL) RangeSize(lo int, hi int) int {
 
 // Returns the keys in the AVL in level order
 func (t *AVL) LevelOrder() []int {
-	queue := make([]*Node, 0)
-	res := make([]int, 0)
-	if t.root != nil {
-		queue = append(queue, t.root)
-	}
-	for len(queue) != 0 {
-		a := queue[0]
-		res = append(res, a.key)
-		queue = queue[1:]
-		for _, element := range []*Node{a.left, a.right} {
-			if element != nil {
-				queue = append(queue, element)
-			}
+	return traversal.LevelOrder(t.root)
+}
+
+// Returns the keys in the AVL grouped by depth, root level first
+func (t *AVL) Levels() [][]int {
+	return traversal.Levels(t.root)
+}
+
+// Returns the keys in the AVL level by level, alternating direction per level
+func (t *AVL) ZigZag() [][]int {
+	return traversal.ZigZag(t.root)
+}
+
+// Returns the keys in the AVL in pre-order (node, left, right)
+func (t *AVL) PreOrder() []int {
+	return traversal.PreOrder(t.root)
+}
+
+// Returns the keys in the AVL in in-order (left, node, right)
+func (t *AVL) InOrder() []int {
+	return traversal.InOrder(t.root)
+}
+
+// Returns the keys in the AVL in post-order (left, right, node)
+func (t *AVL) PostOrder() []int {
+	return traversal.PostOrder(t.root)
+}
+
+// Returns the keys in the AVL in in-order using O(1) extra space
+func (t *AVL) MorrisInOrder() []int {
+	return traversal.MorrisInOrder(t.root, setRight)
+}
+
+// Replaces the right link of the node, for the threads of MorrisInOrder
+func setRight(node *Node, right *Node) {
+	node.right = right
+}
+
+// Returns a deep copy of the tree with the same shape, sizes and heights,
+// so it balances the same way from here on. The copy keeps the comparator
+// but not the observer, and its rotation counters start at zero. O(n).
+func (t *AVL) Clone() *AVL {
+	return &AVL{root: t.clone(t.root), comparator: t.comparator}
+}
+
+func (t *AVL) clone(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.left, copied.right = t.clone(node.left), t.clone(node.right)
+	return &copied
+}
+
+// Pushes node and the chain of its left children onto the stack
+func pushLeft(stack []*Node, node *Node) []*Node {
+	for ; node != nil; node = node.left {
+		stack = append(stack, node)
+	}
+	return stack
+}
+
+// Reports whether the two trees hold the same key-value pairs in the same
+// order, whatever their shapes. Both are walked in order side by side, O(n).
+func (t *AVL) Equal(other *AVL) bool {
+	if t.Size() != other.Size() {
+		return false
+	}
+	s1, s2 := pushLeft(nil, t.root), pushLeft(nil, other.root)
+	for len(s1) != 0 {
+		a, b := s1[len(s1)-1], s2[len(s2)-1]
+		if a.key != b.key || a.val != b.val {
+			return false
 		}
+		s1 = pushLeft(s1[:len(s1)-1], a.right)
+		s2 = pushLeft(s2[:len(s2)-1], b.right)
 	}
-	return res
+	return true
+}
+
+// Reports whether the two trees are identical node for node: the same keys
+// and values at the same places. Meant for test assertions, e.g. that a
+// Clone reproduced the tree exactly.
+func (t *AVL) EqualShape(other *AVL) bool {
+	return t.equalShape(t.root, other.root)
+}
+
+func (t *AVL) equalShape(a *Node, b *Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.key == b.key && a.val == b.val && t.equalShape(a.left, b.left) && t.equalShape(a.right, b.right)
+}
+
+// Removes every key and zeroes the rotation counters;
+// the comparator and observer stay
+func (t *AVL) Clear() {
+	t.root = nil
+	t.leftRotations, t.rightRotations = 0, 0
+}
+
+// Checks the invariants of the tree: symmetric order, subtree sizes,
+// stored heights and the AVL balance condition
+func (t *AVL) check() error {
+	return t.checkNode(t.root, nil, nil)
+}
+
+// lo and hi bound the keys allowed in the subtree, nil meaning unbounded
+func (t *AVL) checkNode(node *Node, lo *int, hi *int) error {
+	if node == nil {
+		return nil
+	}
+	if (lo != nil && t.compare(node.key, *lo) <= 0) || (hi != nil && t.compare(node.key, *hi) >= 0) {
+		return fmt.Errorf("key %d is out of order", node.key)
+	}
+	if node.size != 1+t.size(node.left)+t.size(node.right) {
+		return fmt.Errorf("node %d has size %d", node.key, node.size)
+	}
+	if node.height != 1+utils.MaxOf(t.height(node.left), t.height(node.right)) {
+		return fmt.Errorf("node %d has stale height %d", node.key, node.height)
+	}
+	if utils.Abs(t.delta(node)) > 1 {
+		return fmt.Errorf("node %d has balance factor %d", node.key, t.delta(node))
+	}
+	if err := t.checkNode(node.left, lo, &node.key); err != nil {
+		return err
+	}
+	return t.checkNode(node.right, &node.key, hi)
 }
 
 func main() {}
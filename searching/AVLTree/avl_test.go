@@ -4,6 +4,8 @@
 package AVLTree
 
 import (
+	"algo/searching/conformance"
+	"algo/searching/observer"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -251,6 +253,215 @@ func Test6(t *testing.T) {
 	fmt.Printf("\n")
 }
 
+func Test7(t *testing.T) {
+	var tree *AVL = new(AVL)
+	tree.Put(4, 3)
+	tree.Put(2, 4)
+	tree.Put(1, 3)
+	tree.Put(3, 5)
+	if !reflect.DeepEqual(tree.PreOrder(), []int{2, 1, 4, 3}) {
+		t.Error("PreOrder Wrong")
+	}
+	if !reflect.DeepEqual(tree.InOrder(), []int{1, 2, 3, 4}) {
+		t.Error("InOrder Wrong")
+	}
+	if !reflect.DeepEqual(tree.PostOrder(), []int{1, 3, 4, 2}) {
+		t.Error("PostOrder Wrong")
+	}
+	if !reflect.DeepEqual(tree.Levels(), [][]int{{2}, {1, 4}, {3}}) {
+		t.Error("Levels Wrong")
+	}
+	if !reflect.DeepEqual(tree.ZigZag(), [][]int{{2}, {4, 1}, {3}}) {
+		t.Error("ZigZag Wrong")
+	}
+	if !reflect.DeepEqual(tree.MorrisInOrder(), tree.Keys()) {
+		t.Error("MorrisInOrder Wrong")
+	}
+	if !reflect.DeepEqual(tree.PreOrder(), []int{2, 1, 4, 3}) {
+		t.Error("MorrisInOrder modified the tree")
+	}
+}
+
+func Test8(t *testing.T) {
+	var tree *AVL = new(AVL)
+	if tree.Stats().Height != -1 {
+		t.Error("Empty Tree Wrong")
+	}
+	for i := 1; i <= 7; i++ {
+		tree.Put(i, i)
+	}
+	s := tree.Stats()
+	if s.Size != 7 || s.Height != 2 || tree.Height() != 2 || s.Leaves != 4 {
+		t.Error("Shape Wrong")
+	}
+	// depths: 0 + 1 + 1 + 2 + 2 + 2 + 2
+	if s.InternalPathLength != 10 || !reflect.DeepEqual(s.BalanceFactors, map[int]int{0: 7}) {
+		t.Error("Path Length Wrong")
+	}
+	if s.LeftRotations != 4 || s.RightRotations != 0 {
+		t.Error("Rotations Wrong")
+	}
+}
+
+func Test9(t *testing.T) {
+	var tree *AVL = new(AVL)
+	c := new(observer.Counter)
+	tree.SetObserver(c)
+	for i := 1; i <= 7; i++ {
+		tree.Put(i, i)
+	}
+	if c.Allocations != 7 || c.LeftRotations != 4 || c.RightRotations != 0 {
+		t.Error("Put Counters Wrong")
+	}
+	for i := 8; i <= 1023; i++ {
+		tree.Put(i, i)
+	}
+	// a descent never looks at more than height + 1 nodes
+	c.Reset()
+	for i := 1; i <= 1023; i++ {
+		tree.Get(i)
+	}
+	if c.Visits > 1023*(tree.Height()+1) || c.Comparisons != c.Visits {
+		t.Error("Get Counters Wrong " + strconv.Itoa(c.Visits))
+	}
+	tree.SetObserver(nil)
+	tree.Put(0, 0)
+	if c.Allocations != 0 {
+		t.Error("Observer Not Removed")
+	}
+}
+
+func Test10(t *testing.T) {
+	var tree *AVL = new(AVL)
+	// count words, dropping a word whose count falls to zero
+	for _, w := range []int{3, 1, 3, 2, 3, 1} {
+		tree.Compute(w, func(old int, ok bool) (int, bool) { return old + 1, true })
+	}
+	tree.Compute(2, func(old int, ok bool) (int, bool) { return old - 1, old > 1 })
+	if tree.Size() != 2 || tree.Get(3) != 3 || tree.Get(1) != 2 || tree.Contains(2) {
+		t.Error("Compute Wrong")
+	}
+	if v, loaded := tree.GetOrPut(3, 9); v != 3 || !loaded {
+		t.Error("GetOrPut Wrong")
+	}
+	if v, loaded := tree.GetOrPut(5, 9); v != 9 || loaded || tree.Get(5) != 9 {
+		t.Error("GetOrPut Wrong")
+	}
+	if v, loaded := tree.Swap(5, 0); v != 9 || !loaded || !tree.Contains(5) {
+		t.Error("Swap Wrong")
+	}
+	if v, found := tree.Delete(5); v != 0 || !found || tree.Contains(5) {
+		t.Error("Delete Wrong")
+	}
+	if _, found := tree.Delete(5); found || tree.check() != nil {
+		t.Error("Delete Wrong")
+	}
+	// a Compute looks the key up in a single descent
+	for i := 10; i < 1000; i++ {
+		tree.Put(i, i)
+	}
+	c := new(observer.Counter)
+	tree.SetObserver(c)
+	tree.Compute(500, func(old int, ok bool) (int, bool) { return old + 1, true })
+	if c.Comparisons > tree.Height()+1 || tree.Get(500) != 501 {
+		t.Error("Compute Counters Wrong " + strconv.Itoa(c.Comparisons))
+	}
+}
+
+func Test11(t *testing.T) {
+	var tree *AVL = new(AVL)
+	if _, _, ok := tree.PopMin(); ok || tree.DeleteRange(0, 10) != 0 {
+		t.Error("Empty Wrong")
+	}
+	for i := 0; i < 10000; i++ {
+		tree.Put(i, i*2)
+	}
+	if k, v, ok := tree.PopMin(); k != 0 || v != 0 || !ok {
+		t.Error("PopMin Wrong")
+	}
+	if k, v, ok := tree.PopMax(); k != 9999 || v != 19998 || !ok {
+		t.Error("PopMax Wrong")
+	}
+	if v, ok := tree.Remove(50); v != 100 || !ok || tree.Contains(50) {
+		t.Error("Remove Wrong")
+	}
+	// the split and join only touch the paths to the ends of the range
+	c := new(observer.Counter)
+	tree.SetObserver(c)
+	if n := tree.DeleteRange(10, 8999); n != 8989 {
+		t.Error("DeleteRange Wrong " + strconv.Itoa(n))
+	}
+	if c.Comparisons > 4*tree.Height()+8 {
+		t.Error("DeleteRange Counters Wrong " + strconv.Itoa(c.Comparisons))
+	}
+	tree.SetObserver(nil)
+	if tree.Size() != 1008 || tree.Rank(9000) != 9 || tree.Contains(10) || !tree.Contains(9) || tree.check() != nil {
+		t.Error("DeleteRange Wrong")
+	}
+	if tree.DeleteRange(20, 10) != 0 || tree.DeleteRange(100, 200) != 0 {
+		t.Error("DeleteRange Wrong")
+	}
+}
+
+func Test12(t *testing.T) {
+	var tree *AVL = new(AVL)
+	for _, k := range []int{5, 2, 8, 1, 3, 9} {
+		tree.Put(k, k*10)
+	}
+	c := tree.Clone()
+	if !c.EqualShape(tree) || !c.Equal(tree) || c.Size() != tree.Size() || c.Height() != tree.Height() || c.check() != nil {
+		t.Error("Clone Wrong")
+	}
+	// the copy shares no nodes with the original
+	c.Put(5, 0)
+	c.Put(4, 40)
+	if tree.Get(5) != 50 || tree.Contains(4) || c.Equal(tree) {
+		t.Error("Clone Wrong")
+	}
+	// the same pairs put in another order give another shape
+	other := new(AVL)
+	for _, k := range []int{9, 8, 5, 3, 2, 1} {
+		other.Put(k, k*10)
+	}
+	if !other.Equal(tree) || other.EqualShape(tree) {
+		t.Error("Equal Wrong")
+	}
+	other.Put(9, 0)
+	if other.Equal(tree) {
+		t.Error("Equal Wrong")
+	}
+	// ascending puts rotate, and Clear forgets those rotations
+	for i := 10; i < 20; i++ {
+		tree.Put(i, i)
+	}
+	if s := tree.Stats(); s.LeftRotations == 0 {
+		t.Error("Rotations Wrong")
+	}
+	tree.Clear()
+	if tree.Size() != 0 || c.Size() != 7 || !tree.Equal(new(AVL)) {
+		t.Error("Clear Wrong")
+	}
+	if s := tree.Stats(); s.LeftRotations != 0 || s.RightRotations != 0 {
+		t.Error("Clear Counters Wrong")
+	}
+	tree.Put(1, 1)
+	if tree.Size() != 1 || tree.check() != nil {
+		t.Error("Clear Wrong")
+	}
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapt[*Node](func() *AVL { return new(AVL) }, (*AVL).check))
+}
+
+func TestReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Node](func() *AVL { return NewWithComparator(conformance.Descending) }, (*AVL).check))
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Node](func() *AVL { return new(AVL) }, (*AVL).check))
+}
+
 /* An example of using the errors package
 func (t* AVL) get(n *Node, key int) (val int, err error) {
 	if n == nil {
@@ -0,0 +1,458 @@
+package AVLTree
+
+import (
+	"fmt"
+
+	"algo/utils"
+)
+
+// A node of AVLMultiset: a distinct key with its multiplicity
+type multisetNode struct {
+	key         int
+	count       int // number of copies of key
+	size        int // number of copies of all keys in the subtree
+	height      int
+	left, right *multisetNode
+}
+
+// The struct represents an ordered multiset of ints: an AVL tree holding
+// every distinct key once with the number of its copies. Size, Rank, Select
+// and RangeSize count every copy, so the multiset reads like the sorted
+// sequence of everything added. Every operation is O(log n).
+// The zero value is an empty multiset.
+type AVLMultiset struct {
+	root     *multisetNode
+	distinct int
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+}
+
+func NewMultiset() *AVLMultiset {
+	return new(AVLMultiset)
+}
+
+// Returns an empty multiset ordered by compare
+func NewMultisetWithComparator(compare utils.Comparator) *AVLMultiset {
+	return &AVLMultiset{comparator: compare}
+}
+
+// have such a helper function to avoid visiting nil node
+func (t *AVLMultiset) height(node *multisetNode) int {
+	if node == nil {
+		return -1
+	}
+	return node.height
+}
+
+// have such a helper function to avoid visiting nil node
+func (t *AVLMultiset) size(node *multisetNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// Recomputes the size and height of the node from its children
+func (t *AVLMultiset) update(node *multisetNode) {
+	node.size = node.count + t.size(node.left) + t.size(node.right)
+	node.height = 1 + utils.MaxOf(t.height(node.left), t.height(node.right))
+}
+
+// Returns the number of elements, counting every copy
+func (t *AVLMultiset) Size() int {
+	return t.size(t.root)
+}
+
+// Returns the number of distinct keys
+func (t *AVLMultiset) DistinctSize() int {
+	return t.distinct
+}
+
+func (t *AVLMultiset) IsEmpty() bool {
+	return t.root == nil
+}
+
+func (t *AVLMultiset) get(node *multisetNode, key int) *multisetNode {
+	for node != nil {
+		cmp := t.comparator.Compare(key, node.key)
+		if cmp == 0 {
+			return node
+		} else if cmp < 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return nil
+}
+
+// Returns the number of copies of key, 0 if there are none
+func (t *AVLMultiset) Count(key int) int {
+	if n := t.get(t.root, key); n != nil {
+		return n.count
+	}
+	return 0
+}
+
+// Return true if at least one copy of key is in the multiset
+func (t *AVLMultiset) Contains(key int) bool {
+	return t.get(t.root, key) != nil
+}
+
+func (t *AVLMultiset) delta(node *multisetNode) int {
+	if node == nil {
+		return 0
+	}
+	return t.height(node.left) - t.height(node.right)
+}
+
+// Rotates the given subtree to the left.
+func (t *AVLMultiset) rotateLeft(node *multisetNode) *multisetNode {
+	newHead := node.right
+	node.right = newHead.left
+	newHead.left = node
+	t.update(node)
+	t.update(newHead)
+	return newHead
+}
+
+// Rotates the given subtree to the right.
+func (t *AVLMultiset) rotateRight(node *multisetNode) *multisetNode {
+	newHead := node.left
+	node.left = newHead.right
+	newHead.right = node
+	t.update(node)
+	t.update(newHead)
+	return newHead
+}
+
+// Balance the AVL Tree
+func (t *AVLMultiset) balance(node *multisetNode) *multisetNode {
+	deltaVal := t.delta(node)
+	if utils.Abs(deltaVal) <= 1 {
+		return node
+	}
+	if deltaVal == 2 {
+		if t.delta(node.left) == -1 {
+			node.left = t.rotateLeft(node.left)
+		}
+		return t.rotateRight(node)
+	} else {
+		// deltaVal == -2
+		if t.delta(node.right) == 1 {
+			node.right = t.rotateRight(node.right)
+		}
+		return t.rotateLeft(node)
+	}
+}
+
+func (t *AVLMultiset) add(node *multisetNode, key int) *multisetNode {
+	if node == nil {
+		t.distinct++
+		return &multisetNode{key: key, count: 1, size: 1}
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp < 0 {
+		node.left = t.add(node.left, key)
+	} else if cmp == 0 {
+		node.count++
+	} else {
+		node.right = t.add(node.right, key)
+	}
+	t.update(node)
+	return t.balance(node)
+}
+
+// Adds one copy of key
+func (t *AVLMultiset) Add(key int) {
+	t.root = t.add(t.root, key)
+}
+
+func (t *AVLMultiset) deleteMin(node *multisetNode) *multisetNode {
+	if node.left != nil {
+		node.left = t.deleteMin(node.left)
+		t.update(node)
+		return t.balance(node)
+	}
+	return node.right
+}
+
+func (t *AVLMultiset) findMin(node *multisetNode) *multisetNode {
+	for node != nil && node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+func (t *AVLMultiset) findMax(node *multisetNode) *multisetNode {
+	for node != nil && node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+// Removes one copy of key, or every copy if all is set. Returns the number
+// of copies removed along with the new subtree.
+func (t *AVLMultiset) remove(node *multisetNode, key int, all bool) (*multisetNode, int) {
+	if node == nil {
+		return nil, 0
+	}
+	var removed int
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp < 0 {
+		node.left, removed = t.remove(node.left, key, all)
+	} else if cmp > 0 {
+		node.right, removed = t.remove(node.right, key, all)
+	} else if !all && node.count > 1 {
+		node.count--
+		removed = 1
+	} else {
+		removed = node.count
+		t.distinct--
+		if node.left == nil {
+			return node.right, removed
+		} else if node.right == nil {
+			return node.left, removed
+		}
+		minNode := t.findMin(node.right)
+		node.key = minNode.key
+		node.count = minNode.count
+		node.right = t.deleteMin(node.right)
+	}
+	t.update(node)
+	return t.balance(node), removed
+}
+
+// Removes one copy of key; returns false if there was none
+func (t *AVLMultiset) RemoveOne(key int) bool {
+	var removed int
+	t.root, removed = t.remove(t.root, key, false)
+	return removed > 0
+}
+
+// Removes every copy of key and returns how many there were
+func (t *AVLMultiset) RemoveAll(key int) int {
+	var removed int
+	t.root, removed = t.remove(t.root, key, true)
+	return removed
+}
+
+// Returns the smallest key, and false if the multiset is empty
+func (t *AVLMultiset) Min() (int, bool) {
+	if n := t.findMin(t.root); n != nil {
+		return n.key, true
+	}
+	return 0, false
+}
+
+// Returns the largest key, and false if the multiset is empty
+func (t *AVLMultiset) Max() (int, bool) {
+	if n := t.findMax(t.root); n != nil {
+		return n.key, true
+	}
+	return 0, false
+}
+
+// Returns the largest key less than or equal to key, and false if there is none
+func (t *AVLMultiset) Floor(key int) (int, bool) {
+	var best *multisetNode
+	for node := t.root; node != nil; {
+		cmp := t.comparator.Compare(key, node.key)
+		if cmp == 0 {
+			return node.key, true
+		} else if cmp < 0 {
+			node = node.left
+		} else {
+			best = node
+			node = node.right
+		}
+	}
+	if best == nil {
+		return 0, false
+	}
+	return best.key, true
+}
+
+// Returns the smallest key greater than or equal to key, and false if there is none
+func (t *AVLMultiset) Ceiling(key int) (int, bool) {
+	var best *multisetNode
+	for node := t.root; node != nil; {
+		cmp := t.comparator.Compare(key, node.key)
+		if cmp == 0 {
+			return node.key, true
+		} else if cmp > 0 {
+			node = node.right
+		} else {
+			best = node
+			node = node.left
+		}
+	}
+	if best == nil {
+		return 0, false
+	}
+	return best.key, true
+}
+
+// Returns the element at index k of the sorted sequence of all copies,
+// and false if k is out of range
+func (t *AVLMultiset) Select(k int) (int, bool) {
+	if k < 0 || k >= t.Size() {
+		return 0, false
+	}
+	node := t.root
+	for {
+		left := t.size(node.left)
+		if k < left {
+			node = node.left
+		} else if k < left+node.count {
+			return node.key, true
+		} else {
+			k -= left + node.count
+			node = node.right
+		}
+	}
+}
+
+// Return the number of elements strictly less than `key`, counting every copy
+func (t *AVLMultiset) Rank(key int) int {
+	res := 0
+	for node := t.root; node != nil; {
+		cmp := t.comparator.Compare(key, node.key)
+		if cmp > 0 {
+			res += t.size(node.left) + node.count
+			node = node.right
+		} else if cmp == 0 {
+			return res + t.size(node.left)
+		} else {
+			node = node.left
+		}
+	}
+	return res
+}
+
+// Returns the number of elements in [lo, hi], counting every copy
+func (t *AVLMultiset) RangeSize(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	return t.Rank(hi) + t.Count(hi) - t.Rank(lo)
+}
+
+func (t *AVLMultiset) keys(node *multisetNode, res *[]int) {
+	if node == nil {
+		return
+	}
+	t.keys(node.left, res)
+	for i := 0; i < node.count; i++ {
+		*res = append(*res, node.key)
+	}
+	t.keys(node.right, res)
+}
+
+// Returns every element in ascending order, each key repeated once per copy
+func (t *AVLMultiset) Keys() []int {
+	var res []int
+	t.keys(t.root, &res)
+	return res
+}
+
+// Returns a deep copy of the multiset with the same shape, counts, sizes
+// and heights. The copy keeps the comparator. O(n) for n distinct keys.
+func (t *AVLMultiset) Clone() *AVLMultiset {
+	return &AVLMultiset{root: t.clone(t.root), distinct: t.distinct, comparator: t.comparator}
+}
+
+func (t *AVLMultiset) clone(node *multisetNode) *multisetNode {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.left, copied.right = t.clone(node.left), t.clone(node.right)
+	return &copied
+}
+
+// Pushes node and the chain of its left children onto the stack
+func (t *AVLMultiset) pushLeft(stack []*multisetNode, node *multisetNode) []*multisetNode {
+	for ; node != nil; node = node.left {
+		stack = append(stack, node)
+	}
+	return stack
+}
+
+// Reports whether the two multisets hold the same keys with the same
+// counts, whatever their shapes. Both are walked in order side by side.
+func (t *AVLMultiset) Equal(other *AVLMultiset) bool {
+	if t.distinct != other.distinct || t.Size() != other.Size() {
+		return false
+	}
+	s1, s2 := t.pushLeft(nil, t.root), t.pushLeft(nil, other.root)
+	for len(s1) != 0 {
+		a, b := s1[len(s1)-1], s2[len(s2)-1]
+		if a.key != b.key || a.count != b.count {
+			return false
+		}
+		s1 = t.pushLeft(s1[:len(s1)-1], a.right)
+		s2 = t.pushLeft(s2[:len(s2)-1], b.right)
+	}
+	return true
+}
+
+// Reports whether the two multisets are identical node for node: the same
+// keys and counts at the same places. Meant for test assertions, e.g. that
+// a Clone reproduced the tree exactly.
+func (t *AVLMultiset) EqualShape(other *AVLMultiset) bool {
+	return t.equalShape(t.root, other.root)
+}
+
+func (t *AVLMultiset) equalShape(a *multisetNode, b *multisetNode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.key == b.key && a.count == b.count && t.equalShape(a.left, b.left) && t.equalShape(a.right, b.right)
+}
+
+// Removes every element; the comparator stays
+func (t *AVLMultiset) Clear() {
+	t.root = nil
+	t.distinct = 0
+}
+
+// Checks the invariants of the tree: symmetric order, positive counts,
+// subtree sizes, stored heights, the AVL balance condition and the
+// number of distinct keys
+func (t *AVLMultiset) check() error {
+	distinct, err := t.checkNode(t.root, nil, nil)
+	if err == nil && distinct != t.distinct {
+		err = fmt.Errorf("%d distinct keys recorded, %d in the tree", t.distinct, distinct)
+	}
+	return err
+}
+
+// lo and hi bound the keys allowed in the subtree, nil meaning unbounded.
+// Returns the number of nodes in the subtree.
+func (t *AVLMultiset) checkNode(node *multisetNode, lo *int, hi *int) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+	if (lo != nil && t.comparator.Compare(node.key, *lo) <= 0) || (hi != nil && t.comparator.Compare(node.key, *hi) >= 0) {
+		return 0, fmt.Errorf("key %d is out of order", node.key)
+	}
+	if node.count < 1 {
+		return 0, fmt.Errorf("node %d has count %d", node.key, node.count)
+	}
+	if node.size != node.count+t.size(node.left)+t.size(node.right) {
+		return 0, fmt.Errorf("node %d has size %d", node.key, node.size)
+	}
+	if node.height != 1+utils.MaxOf(t.height(node.left), t.height(node.right)) {
+		return 0, fmt.Errorf("node %d has stale height %d", node.key, node.height)
+	}
+	if utils.Abs(t.delta(node)) > 1 {
+		return 0, fmt.Errorf("node %d has balance factor %d", node.key, t.delta(node))
+	}
+	left, err := t.checkNode(node.left, lo, &node.key)
+	if err != nil {
+		return 0, err
+	}
+	right, err := t.checkNode(node.right, &node.key, hi)
+	return 1 + left + right, err
+}
@@ -0,0 +1,157 @@
+package AVLTree
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestMultiset1(t *testing.T) {
+	ms := NewMultiset()
+	if _, ok := ms.Min(); ok || ms.Size() != 0 || ms.RemoveOne(1) {
+		t.Error("Empty Wrong")
+	}
+	for _, k := range []int{5, 3, 5, 1, 5, 3} {
+		ms.Add(k)
+	}
+	if ms.Size() != 6 || ms.DistinctSize() != 3 || ms.Count(5) != 3 || ms.Count(4) != 0 {
+		t.Error("Add Wrong")
+	}
+	if !reflect.DeepEqual(ms.Keys(), []int{1, 3, 3, 5, 5, 5}) {
+		t.Error("Keys Wrong", ms.Keys())
+	}
+	if ms.Rank(3) != 1 || ms.Rank(4) != 3 || ms.Rank(5) != 3 || ms.Rank(6) != 6 {
+		t.Error("Rank Wrong")
+	}
+	if k, _ := ms.Select(2); k != 3 {
+		t.Error("Select Wrong")
+	}
+	if k, _ := ms.Select(3); k != 5 {
+		t.Error("Select Wrong")
+	}
+	if _, ok := ms.Select(6); ok {
+		t.Error("Select Wrong")
+	}
+	if ms.RangeSize(2, 5) != 5 || ms.RangeSize(3, 3) != 2 || ms.RangeSize(5, 2) != 0 {
+		t.Error("RangeSize Wrong")
+	}
+	if k, _ := ms.Floor(4); k != 3 {
+		t.Error("Floor Wrong")
+	}
+	if k, _ := ms.Ceiling(4); k != 5 {
+		t.Error("Ceiling Wrong")
+	}
+	if !ms.RemoveOne(5) || ms.Count(5) != 2 || ms.Size() != 5 {
+		t.Error("RemoveOne Wrong")
+	}
+	if ms.RemoveAll(5) != 2 || ms.Contains(5) || ms.DistinctSize() != 2 || ms.RemoveAll(5) != 0 {
+		t.Error("RemoveAll Wrong")
+	}
+	if k, _ := ms.Max(); k != 3 || ms.check() != nil {
+		t.Error("Max Wrong")
+	}
+}
+
+// random adds and removals against a sorted slice
+func TestMultiset2(t *testing.T) {
+	r := rand.New(rand.NewPCG(45, 45))
+	ms := NewMultiset()
+	var ref []int
+	for i := 0; i < 5000; i++ {
+		k := r.IntN(40)
+		switch r.IntN(4) {
+		case 0, 1:
+			ms.Add(k)
+			ref = append(ref, k)
+			slices.Sort(ref)
+		case 2:
+			j := sort.SearchInts(ref, k)
+			found := j < len(ref) && ref[j] == k
+			if ms.RemoveOne(k) != found {
+				t.Fatal("RemoveOne Wrong", i)
+			}
+			if found {
+				ref = slices.Delete(ref, j, j+1)
+			}
+		case 3:
+			lo, hi := sort.SearchInts(ref, k), sort.SearchInts(ref, k+1)
+			if ms.RemoveAll(k) != hi-lo {
+				t.Fatal("RemoveAll Wrong", i)
+			}
+			ref = slices.Delete(ref, lo, hi)
+		}
+		lo, hi := sort.SearchInts(ref, k), sort.SearchInts(ref, k+1)
+		if ms.Size() != len(ref) || ms.Rank(k) != lo || ms.Count(k) != hi-lo || ms.RangeSize(k, k+5) != sort.SearchInts(ref, k+6)-lo {
+			t.Fatal("Size/Rank/Count Wrong", i)
+		}
+		if len(ref) > 0 {
+			j := r.IntN(len(ref))
+			if got, _ := ms.Select(j); got != ref[j] {
+				t.Fatal("Select Wrong", i)
+			}
+		}
+		if err := ms.check(); err != nil {
+			t.Fatal(i, err)
+		}
+	}
+	if !reflect.DeepEqual(ms.Keys(), ref) && len(ref) > 0 {
+		t.Error("Keys Wrong")
+	}
+}
+
+// a reversed comparator makes the multiset count from the largest key
+func TestMultiset3(t *testing.T) {
+	ms := NewMultisetWithComparator(func(a int, b int) int { return b - a })
+	for _, k := range []int{1, 3, 3, 5} {
+		ms.Add(k)
+	}
+	if !reflect.DeepEqual(ms.Keys(), []int{5, 3, 3, 1}) || ms.Rank(3) != 1 || ms.RangeSize(4, 1) != 3 {
+		t.Error("Order Wrong", ms.Keys())
+	}
+	if k, _ := ms.Floor(4); k != 5 {
+		t.Error("Floor Wrong")
+	}
+	if k, _ := ms.Ceiling(4); k != 3 {
+		t.Error("Ceiling Wrong")
+	}
+	if k, _ := ms.Min(); k != 5 || ms.check() != nil {
+		t.Error("Min Wrong")
+	}
+}
+
+func TestMultiset4(t *testing.T) {
+	ms := NewMultisetWithComparator(func(a int, b int) int { return b - a })
+	for _, k := range []int{4, 2, 6, 1, 3, 5, 7, 8, 3} {
+		ms.Add(k)
+	}
+	c := ms.Clone()
+	if !c.Equal(ms) || !c.EqualShape(ms) || c.check() != nil {
+		t.Error("Clone Wrong")
+	}
+	c.RemoveOne(3)
+	if c.Equal(ms) || c.EqualShape(ms) || ms.Count(3) != 2 || c.Count(3) != 1 {
+		t.Error("Clone Shares Storage")
+	}
+	c.Add(3)
+	if !c.Equal(ms) {
+		t.Error("Equal Wrong")
+	}
+	// the same elements added from the largest: equal, in another shape
+	sorted := NewMultisetWithComparator(func(a int, b int) int { return b - a })
+	for _, k := range ms.Keys() {
+		sorted.Add(k)
+	}
+	if !sorted.Equal(ms) || sorted.EqualShape(ms) {
+		t.Error("Equal Wrong")
+	}
+	c.Clear()
+	if c.Size() != 0 || c.DistinctSize() != 0 || c.Keys() != nil || c.check() != nil {
+		t.Error("Clear Wrong")
+	}
+	c.Add(1)
+	if k, _ := c.Min(); k != 1 || c.Size() != 1 || c.check() != nil {
+		t.Error("Add After Clear Wrong")
+	}
+}
@@ -0,0 +1,950 @@
+// A symbol table implemented with a B+ tree.
+// Key-value pairs live only in the leaves, which are chained left to right
+// so that range scans walk the leaves without going back up the tree.
+// Internal nodes hold separator keys and, for every child, the number of
+// pairs below it, which makes Rank and Select O(degree * log n).
+// Wide nodes keep the tree shallow and the keys of a node contiguous in
+// memory, which suits very large tables far better than one node per key.
+// https://en.wikipedia.org/wiki/B%2B_tree
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/BTree.java.html
+package bTree
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"algo/utils"
+)
+
+const DefaultDegree = 32
+
+// A key-value pair, as returned by Floor, Ceiling and Select
+type Entry struct {
+	key int
+	val int
+}
+
+// Returns the key of the entry
+func (e *Entry) Key() int {
+	return e.key
+}
+
+// Returns the value of the entry
+func (e *Entry) Val() int {
+	return e.val
+}
+
+type node struct {
+	leaf bool
+	// in a leaf, the keys in order; in an internal node, keys[i] separates
+	// children[i] (keys less than keys[i]) from children[i+1] (keys greater
+	// than or equal to keys[i])
+	keys     []int
+	vals     []int   // leaf only
+	next     *node   // leaf only, the leaf to the right
+	children []*node // internal only
+	counts   []int   // internal only, counts[i] pairs are below children[i]
+}
+
+// The struct represents an ordered symbol table of int key-value pairs.
+// Every node but the root holds between ceil(degree/2) and degree keys
+// (leaves) or children (internal nodes). The zero value is an empty tree
+// of DefaultDegree.
+type BTree struct {
+	root   *node
+	degree int
+	n      int
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+}
+
+// Returns an empty tree whose nodes hold at most degree keys or children
+func New(degree int) *BTree {
+	if degree < 3 {
+		panic("bTree: degree must be at least 3")
+	}
+	return &BTree{degree: degree}
+}
+
+// Returns a tree holding the given pairs, built bottom-up in O(n).
+// The keys must be strictly ascending.
+func NewFromSorted(degree int, keys []int, vals []int) *BTree {
+	return NewFromSortedWithComparator(degree, keys, vals, nil)
+}
+
+// Same as NewFromSorted, for keys strictly ascending in the order of compare
+func NewFromSortedWithComparator(degree int, keys []int, vals []int, compare utils.Comparator) *BTree {
+	t := New(degree)
+	t.comparator = compare
+	if len(keys) != len(vals) {
+		panic("bTree: need as many values as keys")
+	}
+	for i := 1; i < len(keys); i++ {
+		if t.comparator.Compare(keys[i-1], keys[i]) >= 0 {
+			panic("bTree: keys are not strictly ascending")
+		}
+	}
+	if len(keys) == 0 {
+		return t
+	}
+	// fill the leaves as evenly as possible, then every level above
+	var level []*node
+	for _, r := range t.chunks(len(keys)) {
+		leaf := &node{leaf: true}
+		leaf.keys = append(leaf.keys, keys[r[0]:r[1]]...)
+		leaf.vals = append(leaf.vals, vals[r[0]:r[1]]...)
+		if len(level) > 0 {
+			level[len(level)-1].next = leaf
+		}
+		level = append(level, leaf)
+	}
+	for len(level) > 1 {
+		var parents []*node
+		for _, r := range t.chunks(len(level)) {
+			parent := &node{}
+			for i, child := range level[r[0]:r[1]] {
+				if i > 0 {
+					parent.keys = append(parent.keys, t.minKey(child))
+				}
+				parent.children = append(parent.children, child)
+				parent.counts = append(parent.counts, t.count(child))
+			}
+			parents = append(parents, parent)
+		}
+		level = parents
+	}
+	t.root = level[0]
+	t.n = len(keys)
+	return t
+}
+
+// Cuts n items into the fewest runs of at most degree items,
+// as even as possible, returned as [start, end) pairs
+func (t *BTree) chunks(n int) [][2]int {
+	parts := (n + t.degree - 1) / t.degree
+	var res [][2]int
+	start := 0
+	for i := 0; i < parts; i++ {
+		end := start + n/parts
+		if i < n%parts {
+			end++
+		}
+		res = append(res, [2]int{start, end})
+		start = end
+	}
+	return res
+}
+
+// Sets up the zero value on first write
+func (t *BTree) lazyInit() {
+	if t.degree == 0 {
+		t.degree = DefaultDegree
+	}
+}
+
+// Returns an empty tree of DefaultDegree ordered by compare
+func NewWithComparator(compare utils.Comparator) *BTree {
+	return &BTree{comparator: compare}
+}
+
+// The fewest keys (leaves) or children (internal nodes) a non-root node may hold
+func (t *BTree) minFill() int {
+	return (t.degree + 1) / 2
+}
+
+// Returns how many keys (leaves) or children (internal nodes) x holds
+func (t *BTree) fill(x *node) int {
+	if x.leaf {
+		return len(x.keys)
+	}
+	return len(x.children)
+}
+
+// Returns the number of pairs below x
+func (t *BTree) count(x *node) int {
+	if x.leaf {
+		return len(x.keys)
+	}
+	res := 0
+	for _, c := range x.counts {
+		res += c
+	}
+	return res
+}
+
+// Returns the smallest key below x
+func (t *BTree) minKey(x *node) int {
+	for !x.leaf {
+		x = x.children[0]
+	}
+	return x.keys[0]
+}
+
+// Returns the number of keys of x less than key
+func (t *BTree) lowerBound(x *node, key int) int {
+	return sort.Search(len(x.keys), func(i int) bool { return t.comparator.Compare(x.keys[i], key) >= 0 })
+}
+
+// Returns the index of the child of x that key belongs to
+func (t *BTree) childIndex(x *node, key int) int {
+	return sort.Search(len(x.keys), func(i int) bool { return t.comparator.Compare(x.keys[i], key) > 0 })
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *BTree) Size() int {
+	return t.n
+}
+
+// Returns the number of levels below the root, -1 for an empty tree
+func (t *BTree) Height() int {
+	if t.root == nil {
+		return -1
+	}
+	h := 0
+	for x := t.root; !x.leaf; x = x.children[0] {
+		h++
+	}
+	return h
+}
+
+// Returns the leaf that key belongs to, nil for an empty tree
+func (t *BTree) findLeaf(key int) *node {
+	x := t.root
+	if x == nil {
+		return nil
+	}
+	for !x.leaf {
+		x = x.children[t.childIndex(x, key)]
+	}
+	return x
+}
+
+// Get value by key, return 0 if not exist
+func (t *BTree) Get(key int) int {
+	leaf := t.findLeaf(key)
+	if leaf == nil {
+		return 0
+	}
+	if i := t.lowerBound(leaf, key); i < len(leaf.keys) && t.comparator.Compare(leaf.keys[i], key) == 0 {
+		return leaf.vals[i]
+	}
+	return 0
+}
+
+// Return true if the key exists in the symbol table
+func (t *BTree) Contains(key int) bool {
+	leaf := t.findLeaf(key)
+	if leaf == nil {
+		return false
+	}
+	i := t.lowerBound(leaf, key)
+	return i < len(leaf.keys) && t.comparator.Compare(leaf.keys[i], key) == 0
+}
+
+// Inserts the pair below x, splitting overfull children on the way back up.
+// Returns true if the key was not in the tree before.
+func (t *BTree) put(x *node, key int, val int) bool {
+	if x.leaf {
+		i := t.lowerBound(x, key)
+		if i < len(x.keys) && t.comparator.Compare(x.keys[i], key) == 0 {
+			x.vals[i] = val
+			return false
+		}
+		x.keys = insertAt(x.keys, i, key)
+		x.vals = insertAt(x.vals, i, val)
+		return true
+	}
+	i := t.childIndex(x, key)
+	added := t.put(x.children[i], key, val)
+	if added {
+		x.counts[i]++
+	}
+	if t.fill(x.children[i]) > t.degree {
+		t.splitChild(x, i)
+	}
+	return added
+}
+
+// Splits the overfull child i of x into two siblings
+func (t *BTree) splitChild(x *node, i int) {
+	child := x.children[i]
+	sibling := &node{leaf: child.leaf}
+	var sep int
+	if child.leaf {
+		mid := len(child.keys) / 2
+		sibling.keys = append([]int(nil), child.keys[mid:]...)
+		sibling.vals = append([]int(nil), child.vals[mid:]...)
+		child.keys = child.keys[:mid:mid]
+		child.vals = child.vals[:mid:mid]
+		sibling.next = child.next
+		child.next = sibling
+		sep = sibling.keys[0]
+	} else {
+		mid := len(child.children) / 2
+		sep = child.keys[mid-1]
+		sibling.keys = append([]int(nil), child.keys[mid:]...)
+		sibling.children = append([]*node(nil), child.children[mid:]...)
+		sibling.counts = append([]int(nil), child.counts[mid:]...)
+		child.keys = child.keys[: mid-1 : mid-1]
+		child.children = child.children[:mid:mid]
+		child.counts = child.counts[:mid:mid]
+	}
+	x.keys = insertAt(x.keys, i, sep)
+	x.children = insertAt(x.children, i+1, sibling)
+	x.counts[i] = t.count(child)
+	x.counts = insertAt(x.counts, i+1, t.count(sibling))
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *BTree) Put(key int, val int) {
+	t.lazyInit()
+	if t.root == nil {
+		t.root = &node{leaf: true}
+	}
+	if t.put(t.root, key, val) {
+		t.n++
+	}
+	if t.fill(t.root) > t.degree {
+		// grow a new root above the old one and split the old one
+		t.root = &node{children: []*node{t.root}, counts: []int{t.count(t.root)}}
+		t.splitChild(t.root, 0)
+	}
+}
+
+// Removes the key below x, refilling underfull children on the way back up.
+// Returns true if the key was in the tree.
+func (t *BTree) delete(x *node, key int) bool {
+	if x.leaf {
+		i := t.lowerBound(x, key)
+		if i == len(x.keys) || t.comparator.Compare(x.keys[i], key) != 0 {
+			return false
+		}
+		x.keys = removeAt(x.keys, i)
+		x.vals = removeAt(x.vals, i)
+		return true
+	}
+	i := t.childIndex(x, key)
+	removed := t.delete(x.children[i], key)
+	if removed {
+		x.counts[i]--
+	}
+	if t.fill(x.children[i]) < t.minFill() {
+		t.refill(x, i)
+	}
+	return removed
+}
+
+// Brings the underfull child i of x back to its minimum fill,
+// borrowing from a sibling that can spare one or merging with a sibling
+func (t *BTree) refill(x *node, i int) {
+	child := x.children[i]
+	if i > 0 && t.fill(x.children[i-1]) > t.minFill() {
+		// borrow the last key or child of the left sibling
+		left := x.children[i-1]
+		moved := 1
+		if child.leaf {
+			last := len(left.keys) - 1
+			child.keys = insertAt(child.keys, 0, left.keys[last])
+			child.vals = insertAt(child.vals, 0, left.vals[last])
+			left.keys = left.keys[:last]
+			left.vals = left.vals[:last]
+			x.keys[i-1] = child.keys[0]
+		} else {
+			last := len(left.children) - 1
+			moved = left.counts[last]
+			child.keys = insertAt(child.keys, 0, x.keys[i-1])
+			child.children = insertAt(child.children, 0, left.children[last])
+			child.counts = insertAt(child.counts, 0, moved)
+			x.keys[i-1] = left.keys[last-1]
+			left.keys = left.keys[:last-1]
+			left.children = left.children[:last]
+			left.counts = left.counts[:last]
+		}
+		x.counts[i-1] -= moved
+		x.counts[i] += moved
+	} else if i+1 < len(x.children) && t.fill(x.children[i+1]) > t.minFill() {
+		// borrow the first key or child of the right sibling
+		right := x.children[i+1]
+		moved := 1
+		if child.leaf {
+			child.keys = append(child.keys, right.keys[0])
+			child.vals = append(child.vals, right.vals[0])
+			right.keys = removeAt(right.keys, 0)
+			right.vals = removeAt(right.vals, 0)
+			x.keys[i] = right.keys[0]
+		} else {
+			moved = right.counts[0]
+			child.keys = append(child.keys, x.keys[i])
+			child.children = append(child.children, right.children[0])
+			child.counts = append(child.counts, moved)
+			x.keys[i] = right.keys[0]
+			right.keys = removeAt(right.keys, 0)
+			right.children = removeAt(right.children, 0)
+			right.counts = removeAt(right.counts, 0)
+		}
+		x.counts[i+1] -= moved
+		x.counts[i] += moved
+	} else if i > 0 {
+		t.merge(x, i-1)
+	} else {
+		t.merge(x, i)
+	}
+}
+
+// Merges child i+1 of x into child i
+func (t *BTree) merge(x *node, i int) {
+	left, right := x.children[i], x.children[i+1]
+	if left.leaf {
+		left.keys = append(left.keys, right.keys...)
+		left.vals = append(left.vals, right.vals...)
+		left.next = right.next
+	} else {
+		left.keys = append(append(left.keys, x.keys[i]), right.keys...)
+		left.children = append(left.children, right.children...)
+		left.counts = append(left.counts, right.counts...)
+	}
+	x.counts[i] += x.counts[i+1]
+	x.keys = removeAt(x.keys, i)
+	x.children = removeAt(x.children, i+1)
+	x.counts = removeAt(x.counts, i+1)
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *BTree) Delete(key int) {
+	if t.root == nil {
+		return
+	}
+	if t.delete(t.root, key) {
+		t.n--
+	}
+	if !t.root.leaf && len(t.root.children) == 1 {
+		// the root lost its last separator, the tree gets one level shorter
+		t.root = t.root.children[0]
+	} else if t.root.leaf && len(t.root.keys) == 0 {
+		t.root = nil
+	}
+}
+
+// Returns the leftmost leaf, nil for an empty tree
+func (t *BTree) firstLeaf() *node {
+	x := t.root
+	for x != nil && !x.leaf {
+		x = x.children[0]
+	}
+	return x
+}
+
+// Returns the rightmost leaf, nil for an empty tree
+func (t *BTree) lastLeaf() *node {
+	x := t.root
+	for x != nil && !x.leaf {
+		x = x.children[len(x.children)-1]
+	}
+	return x
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (t *BTree) DeleteMin() {
+	if t.n == 0 {
+		return
+	}
+	t.Delete(t.firstLeaf().keys[0])
+}
+
+// Removes the largest key and associated value from the symbol table
+func (t *BTree) DeleteMax() {
+	if t.n == 0 {
+		return
+	}
+	leaf := t.lastLeaf()
+	t.Delete(leaf.keys[len(leaf.keys)-1])
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *BTree) Remove(key int) (val int, ok bool) {
+	leaf := t.findLeaf(key)
+	if leaf == nil {
+		return 0, false
+	}
+	i := t.lowerBound(leaf, key)
+	if i == len(leaf.keys) || t.comparator.Compare(leaf.keys[i], key) != 0 {
+		return 0, false
+	}
+	val = leaf.vals[i]
+	t.Delete(key)
+	return val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the tree is empty
+func (t *BTree) PopMin() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Min()
+	t.DeleteMin()
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the tree is empty
+func (t *BTree) PopMax() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Max()
+	t.DeleteMax()
+	return key, val, true
+}
+
+// Appends the pairs or children of right to left, with sep separating
+// the two in an internal node. Both are at the same height.
+func (t *BTree) absorb(left *node, sep int, right *node) {
+	if left.leaf {
+		left.keys = append(left.keys, right.keys...)
+		left.vals = append(left.vals, right.vals...)
+		left.next = right.next
+		return
+	}
+	left.keys = append(append(left.keys, sep), right.keys...)
+	left.children = append(left.children, right.children...)
+	left.counts = append(left.counts, right.counts...)
+}
+
+// Joins two trees of heights hl and hr, -1 for an empty one, where every
+// key of left is less than sep and every key of right at least sep. The
+// root of the shorter tree is absorbed by the node at its height on the
+// spine of the taller one, which never leaves that node underfull, and
+// overfull nodes are split on the way back up as in Put.
+// O(degree * (|hl-hr|+1)). Returns the tree and its height.
+func (t *BTree) join(left *node, hl int, sep int, right *node, hr int) (*node, int) {
+	if left == nil {
+		return right, hr
+	}
+	if right == nil {
+		return left, hl
+	}
+	root, h := left, hl
+	if hl == hr {
+		t.absorb(left, sep, right)
+	} else if hl > hr {
+		t.joinRight(left, hl, sep, right, hr)
+	} else {
+		t.joinLeft(left, hl, sep, right, hr)
+		root, h = right, hr
+	}
+	if t.fill(root) > t.degree {
+		root = &node{children: []*node{root}, counts: []int{t.count(root)}}
+		t.splitChild(root, 0)
+		h++
+	}
+	return root, h
+}
+
+// Joins right, of height hr, onto the right spine of x, of height h > hr
+func (t *BTree) joinRight(x *node, h int, sep int, right *node, hr int) {
+	last := len(x.children) - 1
+	if h == hr+1 {
+		t.absorb(x.children[last], sep, right)
+	} else {
+		t.joinRight(x.children[last], h-1, sep, right, hr)
+	}
+	x.counts[last] = t.count(x.children[last])
+	if t.fill(x.children[last]) > t.degree {
+		t.splitChild(x, last)
+	}
+}
+
+// Joins left, of height hl, onto the left spine of x, of height h > hl.
+// left absorbs the first child rather than the other way round, so the
+// leaf chained to it still is.
+func (t *BTree) joinLeft(left *node, hl int, sep int, x *node, h int) {
+	if h == hl+1 {
+		t.absorb(left, sep, x.children[0])
+		x.children[0] = left
+	} else {
+		t.joinLeft(left, hl, sep, x.children[0], h-1)
+	}
+	x.counts[0] = t.count(x.children[0])
+	if t.fill(x.children[0]) > t.degree {
+		t.splitChild(x, 0)
+	}
+}
+
+// Splits a subtree of height h into the keys before key and the rest, where
+// before means less than key, or less than or equal to it if orEqual is set.
+// Along the search path the children on either side are joined onto the
+// two halves, O(degree * log n) in all since the joined heights only grow.
+// Returns both halves with their heights. The last leaf of the first half
+// is still chained to the leaf that followed it.
+func (t *BTree) splitAt(x *node, h int, key int, orEqual bool) (*node, int, *node, int) {
+	if x == nil {
+		return nil, -1, nil, -1
+	}
+	if x.leaf {
+		i := t.lowerBound(x, key)
+		if orEqual {
+			i = t.childIndex(x, key)
+		}
+		if i == 0 {
+			return nil, -1, x, 0
+		}
+		if i == len(x.keys) {
+			return x, 0, nil, -1
+		}
+		right := &node{leaf: true, next: x.next}
+		right.keys = append([]int(nil), x.keys[i:]...)
+		right.vals = append([]int(nil), x.vals[i:]...)
+		x.keys, x.vals = x.keys[:i:i], x.vals[:i:i]
+		return x, 0, right, 0
+	}
+	i := t.childIndex(x, key)
+	left, hl, right, hr := t.splitAt(x.children[i], h-1, key, orEqual)
+	if i > 0 {
+		before, hb := x.children[0], h-1
+		if i > 1 {
+			before = &node{keys: x.keys[: i-1 : i-1], children: x.children[:i:i], counts: x.counts[:i:i]}
+			hb = h
+		}
+		left, hl = t.join(before, hb, x.keys[i-1], left, hl)
+	}
+	if i < len(x.keys) {
+		after, ha := x.children[i+1], h-1
+		if i+2 < len(x.children) {
+			after = &node{keys: x.keys[i+1:], children: x.children[i+1:], counts: x.counts[i+1:]}
+			ha = h
+		}
+		right, hr = t.join(right, hr, x.keys[i], after, ha)
+	}
+	return left, hl, right, hr
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// Subtrees inside the range are dropped whole by the two splits, so only
+// the boundary paths are rebuilt: O(degree * log n) however many keys go.
+func (t *BTree) DeleteRange(lo int, hi int) int {
+	if t.root == nil || t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	left, hl, rest, hr := t.splitAt(t.root, t.Height(), lo, false)
+	middle, _, right, hr := t.splitAt(rest, hr, hi, true)
+	removed := 0
+	if middle != nil {
+		removed = t.count(middle)
+	}
+	// chain the last leaf of left to the first leaf of right
+	var first *node
+	sep := 0
+	if right != nil {
+		first = right
+		for !first.leaf {
+			first = first.children[0]
+		}
+		sep = first.keys[0]
+	}
+	if left != nil {
+		last := left
+		for !last.leaf {
+			last = last.children[len(last.children)-1]
+		}
+		last.next = first
+	}
+	t.root, _ = t.join(left, hl, sep, right, hr)
+	t.n -= removed
+	return removed
+}
+
+func (t *BTree) Min() (key int, val int) {
+	leaf := t.firstLeaf()
+	return leaf.keys[0], leaf.vals[0]
+}
+
+func (t *BTree) Max() (key int, val int) {
+	leaf := t.lastLeaf()
+	last := len(leaf.keys) - 1
+	return leaf.keys[last], leaf.vals[last]
+}
+
+// Returns the entry with the largest key in the symbol table less than or equal to key.
+// One descent finds the leaf of key; when every key there is larger, the
+// floor is the last key of the nearest subtree left of the search path.
+func (t *BTree) Floor(key int) *Entry {
+	x := t.root
+	if x == nil {
+		return nil
+	}
+	var left *node
+	for !x.leaf {
+		i := t.childIndex(x, key)
+		if i > 0 {
+			left = x.children[i-1]
+		}
+		x = x.children[i]
+	}
+	// the keys of the leaf before index i are at most key
+	if i := t.childIndex(x, key); i > 0 {
+		return &Entry{x.keys[i-1], x.vals[i-1]}
+	}
+	if left == nil {
+		return nil
+	}
+	for !left.leaf {
+		left = left.children[len(left.children)-1]
+	}
+	last := len(left.keys) - 1
+	return &Entry{left.keys[last], left.vals[last]}
+}
+
+// Returns the entry with the smallest key in the symbol table greater than or equal to key.
+func (t *BTree) Ceiling(key int) *Entry {
+	leaf := t.findLeaf(key)
+	if leaf == nil {
+		return nil
+	}
+	i := t.lowerBound(leaf, key)
+	if i == len(leaf.keys) {
+		// every key of this leaf is smaller, the next leaf starts above key
+		leaf, i = leaf.next, 0
+	}
+	if leaf == nil {
+		return nil
+	}
+	return &Entry{leaf.keys[i], leaf.vals[i]}
+}
+
+// Return the entry in the symbol table whose rank is k
+func (t *BTree) Select(k int) *Entry {
+	if k < 0 || k >= t.n {
+		return nil
+	}
+	x := t.root
+	for !x.leaf {
+		i := 0
+		for k >= x.counts[i] {
+			k -= x.counts[i]
+			i++
+		}
+		x = x.children[i]
+	}
+	return &Entry{x.keys[k], x.vals[k]}
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (t *BTree) Rank(key int) int {
+	x := t.root
+	if x == nil {
+		return 0
+	}
+	res := 0
+	for !x.leaf {
+		i := t.childIndex(x, key)
+		for _, c := range x.counts[:i] {
+			res += c
+		}
+		x = x.children[i]
+	}
+	return res + t.lowerBound(x, key)
+}
+
+// Returns all keys in the symbol table as an Iterable
+func (t *BTree) Keys() []int {
+	var res []int
+	for leaf := t.firstLeaf(); leaf != nil; leaf = leaf.next {
+		res = append(res, leaf.keys...)
+	}
+	return res
+}
+
+// Returns all keys in the symbol table in the given range,
+// scanning the chained leaves from the one holding lo
+func (t *BTree) RangeKeys(lo int, hi int) []int {
+	var res []int
+	leaf := t.findLeaf(lo)
+	if leaf == nil {
+		return res
+	}
+	for i := t.lowerBound(leaf, lo); leaf != nil; leaf, i = leaf.next, 0 {
+		for ; i < len(leaf.keys); i++ {
+			if t.comparator.Compare(leaf.keys[i], hi) > 0 {
+				return res
+			}
+			res = append(res, leaf.keys[i])
+		}
+	}
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (t *BTree) RangeSize(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	if t.Contains(hi) {
+		return t.Rank(hi) - t.Rank(lo) + 1
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
+// Returns a deep copy of the tree with the same nodes and leaf chain.
+// The copy keeps the degree and the comparator. O(n).
+func (t *BTree) Clone() *BTree {
+	c := &BTree{degree: t.degree, n: t.n, comparator: t.comparator}
+	if t.root != nil {
+		var prev *node
+		c.root = t.clone(t.root, &prev)
+	}
+	return c
+}
+
+// Copies the subtree of x, chaining its leaves after *prev, the last leaf
+// copied so far
+func (t *BTree) clone(x *node, prev **node) *node {
+	copied := &node{leaf: x.leaf, keys: slices.Clone(x.keys), vals: slices.Clone(x.vals), counts: slices.Clone(x.counts)}
+	if x.leaf {
+		if *prev != nil {
+			(*prev).next = copied
+		}
+		*prev = copied
+		return copied
+	}
+	copied.children = make([]*node, len(x.children))
+	for i, child := range x.children {
+		copied.children[i] = t.clone(child, prev)
+	}
+	return copied
+}
+
+// Reports whether the two trees hold the same key-value pairs in the same
+// order, whatever their degrees and shapes. Both leaf chains are walked
+// side by side, O(n).
+func (t *BTree) Equal(other *BTree) bool {
+	if t.n != other.n {
+		return false
+	}
+	a, b := t.firstLeaf(), other.firstLeaf()
+	for i, j, k := 0, 0, 0; k < t.n; i, j, k = i+1, j+1, k+1 {
+		for i == len(a.keys) {
+			a, i = a.next, 0
+		}
+		for j == len(b.keys) {
+			b, j = b.next, 0
+		}
+		if a.keys[i] != b.keys[j] || a.vals[i] != b.vals[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether the two trees are identical node for node: the same
+// separators, counts and pairs at the same places. Meant for test
+// assertions, e.g. that a Clone reproduced the tree exactly.
+func (t *BTree) EqualShape(other *BTree) bool {
+	return t.equalShape(t.root, other.root)
+}
+
+func (t *BTree) equalShape(x *node, y *node) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.leaf != y.leaf || !slices.Equal(x.keys, y.keys) || !slices.Equal(x.vals, y.vals) ||
+		!slices.Equal(x.counts, y.counts) || len(x.children) != len(y.children) {
+		return false
+	}
+	for i := range x.children {
+		if !t.equalShape(x.children[i], y.children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes every key; the degree and the comparator stay
+func (t *BTree) Clear() {
+	t.root = nil
+	t.n = 0
+}
+
+// Inserts v at index i of s, shifting the rest to the right
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+// Returns s without the element at index i
+func removeAt[T any](s []T, i int) []T {
+	return append(s[:i], s[i+1:]...)
+}
+
+// Checks the invariants of the tree: symmetric order, fill bounds,
+// per-child counts, equal leaf depth and the leaf chain
+func (t *BTree) check() error {
+	if t.root == nil {
+		if t.n != 0 {
+			return fmt.Errorf("empty tree counts %d pairs", t.n)
+		}
+		return nil
+	}
+	var leaves []*node
+	if _, err := t.checkNode(t.root, nil, nil, 0, t.Height(), &leaves); err != nil {
+		return err
+	}
+	total := 0
+	for i, leaf := range leaves {
+		total += len(leaf.keys)
+		if (i+1 < len(leaves) && leaf.next != leaves[i+1]) || (i+1 == len(leaves) && leaf.next != nil) {
+			return fmt.Errorf("leaf %d is chained out of order", i)
+		}
+	}
+	if total != t.n {
+		return fmt.Errorf("%d pairs are counted as %d", total, t.n)
+	}
+	return nil
+}
+
+// lo and hi bound the keys allowed below x (lo inclusive, hi exclusive),
+// nil meaning unbounded. Returns the number of pairs below x.
+func (t *BTree) checkNode(x *node, lo *int, hi *int, depth int, height int, leaves *[]*node) (int, error) {
+	for i, key := range x.keys {
+		if (lo != nil && t.comparator.Compare(key, *lo) < 0) || (hi != nil && t.comparator.Compare(key, *hi) >= 0) {
+			return 0, fmt.Errorf("key %d is out of order", key)
+		}
+		if i > 0 && t.comparator.Compare(x.keys[i-1], key) >= 0 {
+			return 0, fmt.Errorf("key %d is out of order", key)
+		}
+	}
+	if x != t.root && (t.fill(x) < t.minFill() || t.fill(x) > t.degree) {
+		return 0, fmt.Errorf("node at depth %d holds %d", depth, t.fill(x))
+	}
+	if x.leaf {
+		if depth != height {
+			return 0, fmt.Errorf("leaf at depth %d, want %d", depth, height)
+		}
+		*leaves = append(*leaves, x)
+		return len(x.keys), nil
+	}
+	if len(x.children) != len(x.keys)+1 || len(x.counts) != len(x.children) {
+		return 0, fmt.Errorf("node at depth %d has %d keys and %d children", depth, len(x.keys), len(x.children))
+	}
+	total := 0
+	for i, child := range x.children {
+		childLo, childHi := lo, hi
+		if i > 0 {
+			childLo = &x.keys[i-1]
+		}
+		if i < len(x.keys) {
+			childHi = &x.keys[i]
+		}
+		c, err := t.checkNode(child, childLo, childHi, depth+1, height, leaves)
+		if err != nil {
+			return 0, err
+		}
+		if c != x.counts[i] {
+			return 0, fmt.Errorf("child %d at depth %d counts %d pairs, has %d", i, depth, x.counts[i], c)
+		}
+		total += c
+	}
+	return total, nil
+}
@@ -0,0 +1,177 @@
+package bTree
+
+import (
+	"algo/searching/AVLTree"
+	"algo/searching/conformance"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	var tree *BTree = new(BTree)
+	if tree.Size() != 0 || tree.Height() != -1 || tree.Ceiling(1) != nil || tree.Floor(1) != nil {
+		t.Fail()
+	}
+	tree.Put(1, 3)
+	tree.Put(1, 4)
+	tree.Put(2, 3)
+	tree.Put(4, 5)
+	tree.Put(10, 1)
+	if tree.Size() != 4 || !tree.Contains(1) || tree.Get(1) != 4 || tree.Get(0) != 0 {
+		t.Error("Put Wrong")
+	}
+	if e := tree.Floor(3); e == nil || e.Key() != 2 || e.Val() != 3 {
+		t.Error("Floor Wrong")
+	}
+	if e := tree.Ceiling(3); e == nil || e.Key() != 4 {
+		t.Error("Ceiling Wrong")
+	}
+	if e := tree.Select(2); e == nil || e.Key() != 4 || tree.Rank(4) != 2 || tree.Rank(5) != 3 {
+		t.Error("Select/Rank Wrong")
+	}
+	tree.DeleteMin()
+	tree.DeleteMax()
+	tree.Delete(2)
+	minkey, minval := tree.Min()
+	if tree.Size() != 1 || minkey != 4 || minval != 5 {
+		t.Error("Delete Wrong")
+	}
+}
+
+// small degrees split and merge all the time
+func Test2(t *testing.T) {
+	for _, degree := range []int{3, 4, 5} {
+		tree := New(degree)
+		for i := 0; i < 1000; i++ {
+			tree.Put((i*7919)%1000, i)
+			if i%97 == 0 {
+				if err := tree.check(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		if tree.Height() < 3 {
+			t.Error("Tree Too Shallow " + strconv.Itoa(tree.Height()))
+		}
+		if !reflect.DeepEqual(tree.RangeKeys(500, 505), []int{500, 501, 502, 503, 504, 505}) {
+			t.Error("Range Wrong")
+		}
+		for i := 0; i < 1000; i += 2 {
+			tree.Delete(i)
+		}
+		if tree.Size() != 500 || tree.Select(0).Key() != 1 || tree.Rank(999) != 499 || tree.check() != nil {
+			t.Error("Delete Wrong")
+		}
+		for i := 1; i < 1000; i += 2 {
+			tree.Delete(i)
+		}
+		if tree.Size() != 0 || tree.root != nil {
+			t.Error("Delete All Wrong")
+		}
+	}
+}
+
+func Test3(t *testing.T) {
+	for _, degree := range []int{3, 4, 7, 32} {
+		for n := 0; n < 300; n++ {
+			keys := make([]int, n)
+			vals := make([]int, n)
+			for i := range keys {
+				keys[i] = 3 * i
+				vals[i] = i
+			}
+			tree := NewFromSorted(degree, keys, vals)
+			if err := tree.check(); err != nil {
+				t.Fatalf("degree %d, n %d: %v", degree, n, err)
+			}
+			if tree.Size() != n || (n > 0 && tree.Get(3*(n-1)) != n-1) {
+				t.Fatalf("degree %d, n %d: NewFromSorted Wrong", degree, n)
+			}
+			// floors fall back across leaf boundaries
+			for i := 0; i < n; i++ {
+				if e := tree.Floor(3*i + 2); e == nil || e.Key() != 3*i {
+					t.Fatalf("degree %d, n %d: Floor(%d) Wrong", degree, n, 3*i+2)
+				}
+			}
+			if tree.Floor(-1) != nil {
+				t.Fatalf("degree %d, n %d: Floor Wrong", degree, n)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Unsorted Keys Accepted")
+		}
+	}()
+	NewFromSorted(4, []int{1, 3, 2}, []int{0, 0, 0})
+}
+
+// keys sorted by a descending comparator build a descending tree
+func Test4(t *testing.T) {
+	keys := []int{50, 40, 30, 20, 10, 0}
+	tree := NewFromSortedWithComparator(3, keys, []int{5, 4, 3, 2, 1, 0}, conformance.Descending)
+	if k, v := tree.Min(); k != 50 || v != 5 || tree.Rank(20) != 3 || tree.check() != nil {
+		t.Error("NewFromSortedWithComparator Wrong")
+	}
+	tree.Put(45, 0)
+	if !reflect.DeepEqual(tree.Keys(), []int{50, 45, 40, 30, 20, 10, 0}) || tree.check() != nil {
+		t.Error("Put Wrong", tree.Keys())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Ascending Keys Accepted")
+		}
+	}()
+	NewFromSortedWithComparator(4, []int{1, 2, 3}, []int{0, 0, 0}, conformance.Descending)
+}
+
+func TestConformance(t *testing.T) {
+	for _, degree := range []int{3, 4, 8} {
+		conformance.Run(t, conformance.Adapt[*Entry](func() *BTree { return New(degree) }, (*BTree).check))
+	}
+}
+
+func TestReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Entry](func() *BTree { return NewWithComparator(conformance.Descending) }, (*BTree).check))
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Entry](func() *BTree { return New(3) }, (*BTree).check))
+}
+
+// Building a table from sorted keys: bulk loading against repeated Put
+func BenchmarkBuild(b *testing.B) {
+	for _, n := range []int{1e3, 1e5, 1e6} {
+		keys := make([]int, n)
+		vals := make([]int, n)
+		for i := range keys {
+			keys[i] = i
+			vals[i] = rand.Int()
+		}
+		b.Run("NewFromSorted/n="+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewFromSorted(DefaultDegree, keys, vals)
+			}
+		})
+		b.Run("Put/n="+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tree := New(DefaultDegree)
+				for j, key := range keys {
+					tree.Put(key, vals[j])
+				}
+			}
+		})
+		b.Run("AVL/n="+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tree := new(AVLTree.AVL)
+				for j, key := range keys {
+					tree.Put(key, vals[j])
+				}
+			}
+		})
+	}
+}
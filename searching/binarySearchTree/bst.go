@@ -3,6 +3,14 @@
 // https://algs4.cs.princeton.edu/code/javadoc/edu/princeton/cs/algs4/BST.html
 package binarySearchTree
 
+import (
+	"fmt"
+
+	"algo/searching/observer"
+	"algo/searching/traversal"
+	"algo/utils"
+)
+
 const KeyNotExist = "Key Not Exist"
 
 type Node struct {
@@ -12,6 +20,26 @@ type Node struct {
 	left, right *Node
 }
 
+// Returns the key stored in the node
+func (n *Node) Key() int {
+	return n.key
+}
+
+// Returns the value stored in the node
+func (n *Node) Val() int {
+	return n.val
+}
+
+// Returns the left child, nil if there is none
+func (n *Node) Left() *Node {
+	return n.left
+}
+
+// Returns the right child, nil if there is none
+func (n *Node) Right() *Node {
+	return n.right
+}
+
 type BinaryTree interface {
 	IsEmpty() bool
 	Size() int
@@ -20,7 +48,11 @@ type BinaryTree interface {
 	Put(key int, val int)
 	DeleteMin()
 	DeleteMax()
-	Delete(key int)
+	Delete(key int) (int, bool)
+	Remove(key int) (int, bool)
+	PopMin() (int, int, bool)
+	PopMax() (int, int, bool)
+	DeleteRange(lo int, hi int) int // Removes every key in [lo, hi] and returns how many there were
 	Min() int
 	Max() int
 	Floor() int
@@ -31,11 +63,55 @@ type BinaryTree interface {
 	RangeKeys(lo int, hi int) // Returns all keys in the symbol table in the given range.
 	RangeSize(lo int, hi int) // Returns the number of keys in the symbol table in the given range.
 	LevelOrder()
+	Levels()
+	PreOrder()
+	InOrder()
+	PostOrder()
+	ZigZag()
+	MorrisInOrder()
 }
 
 // The struct represents an ordered symbol table of int key-value pairs.
 type BST struct {
 	root *Node
+	// optional instrumentation, nil when unused
+	observer observer.Observer
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+}
+
+// Installs an observer that is notified of comparisons, visits,
+// rotations and allocations; pass nil to remove it
+func (t *BST) SetObserver(o observer.Observer) {
+	t.observer = o
+}
+
+// Returns an empty tree ordered by compare
+func NewWithComparator(compare utils.Comparator) *BST {
+	return &BST{comparator: compare}
+}
+
+// Notifies the observer, then compares the keys in the tree's order
+func (t *BST) compare(a int, b int) int {
+	if t.observer != nil {
+		t.observer.OnCompare(a, b)
+	}
+	return t.comparator.Compare(a, b)
+}
+
+// Reports that the node is looked at
+func (t *BST) visit(node *Node) {
+	if t.observer != nil {
+		t.observer.OnVisit(node.key)
+	}
+}
+
+// Allocates a node holding a single key-value pair
+func (t *BST) newNode(key int, val int) *Node {
+	if t.observer != nil {
+		t.observer.OnAlloc(key)
+	}
+	return &Node{key: key, val: val, size: 1}
 }
 
 // have such a helper function to avoid visiting nil node
@@ -52,14 +128,28 @@ func (t *BST) Size() int {
 	return t.size(t.root)
 }
 
+// Returns the height of the tree, -1 if it is empty.
+// Nodes do not store their height, so this walks the whole tree.
+func (t *BST) Height() int {
+	return traversal.Height(t.root)
+}
+
+// Returns the shape of the tree; a BST never rotates,
+// so the rotation counters are always zero
+func (t *BST) Stats() traversal.Stats {
+	return traversal.ShapeOf(t.root)
+}
+
 // Returns the node by key
 func (t *BST) get(n *Node, key int) *Node {
 	if n == nil {
 		return nil
 	} else {
-		if key == n.key {
+		t.visit(n)
+		cmp := t.compare(key, n.key)
+		if cmp == 0 {
 			return n
-		} else if key < n.key {
+		} else if cmp < 0 {
 			return t.get(n.left, key)
 		} else {
 			return t.get(n.right, key)
@@ -85,13 +175,15 @@ func (t *BST) Contains(key int) bool {
 
 func (t *BST) put(node *Node, key int, val int) *Node {
 	if node == nil {
-		return &Node{key, val, 1, nil, nil}
+		return t.newNode(key, val)
 	} else {
-		if key < node.key {
+		t.visit(node)
+		cmp := t.compare(key, node.key)
+		if cmp < 0 {
 			node.left = t.put(node.left, key, val)
 			// have such a t.size helper function to avoid visiting nil node
 			node.size = 1 + t.size(node.left) + t.size(node.right)
-		} else if key == node.key {
+		} else if cmp == 0 {
 			node.key = key
 			node.val = val
 		} else {
@@ -171,33 +263,151 @@ func (t *BST) findMax(node *Node) *Node {
 	return node
 }
 
-func (t *BST) delete(node *Node, key int) *Node {
+// Removes the node from its subtree and returns the new root of the subtree
+func (t *BST) deleteNode(node *Node) *Node {
+	if node.left == nil {
+		return node.right
+	} else if node.right == nil {
+		return node.left
+	}
+	var minNode *Node = t.findMin(node.right)
+	node.key = minNode.key
+	node.val = minNode.val
+	node.right = t.deleteMin(node.right)                   // dont forget node.right =
+	node.size = 1 + t.size(node.left) + t.size(node.right) // dont forget update
+	return node
+}
+
+func (t *BST) compute(node *Node, key int, f func(old int, ok bool) (int, bool)) *Node {
 	if node == nil {
+		if val, keep := f(0, false); keep {
+			return t.newNode(key, val)
+		}
 		return nil
-	} else if node.key == key {
-		if node.left == nil {
-			return node.right
-		} else if node.right == nil {
-			return node.left
-		} else {
-			var minNode *Node = t.findMin(node.right)
-			node.key = minNode.key
-			node.val = minNode.val
-			node.right = t.deleteMin(node.right)                   // dont forget node.right =
-			node.size = 1 + t.size(node.left) + t.size(node.right) // dont forget update
-			return node
+	}
+	t.visit(node)
+	cmp := t.compare(key, node.key)
+	if cmp == 0 {
+		val, keep := f(node.val, true)
+		if !keep {
+			return t.deleteNode(node)
 		}
-	} else if key < node.key {
-		node.left = t.delete(node.left, key)
+		node.val = val
+		return node
+	} else if cmp < 0 {
+		node.left = t.compute(node.left, key, f)
 	} else {
-		node.right = t.delete(node.right, key)
+		node.right = t.compute(node.right, key, f)
 	}
 	node.size = 1 + t.size(node.left) + t.size(node.right) // dont forget update
 	return node
 }
 
-func (t *BST) Delete(key int) {
-	t.root = t.delete(t.root, key)
+// Calls f with the value of key and whether the key exists, then stores
+// the value f returns, or removes the key if f says not to keep it.
+// The key is looked up once, so a counter is updated in a single descent.
+func (t *BST) Compute(key int, f func(old int, ok bool) (val int, keep bool)) {
+	t.root = t.compute(t.root, key, f)
+}
+
+// Returns the value of key and true if it exists; otherwise puts val and
+// returns it with false
+func (t *BST) GetOrPut(key int, val int) (actual int, loaded bool) {
+	actual = val
+	t.Compute(key, func(old int, ok bool) (int, bool) {
+		if ok {
+			actual, loaded = old, true
+			return old, true
+		}
+		return val, true
+	})
+	return actual, loaded
+}
+
+// Puts val and returns the previous value of key and whether there was one
+func (t *BST) Swap(key int, val int) (previous int, loaded bool) {
+	t.Compute(key, func(old int, ok bool) (int, bool) {
+		previous, loaded = old, ok
+		return val, true
+	})
+	return previous, loaded
+}
+
+// Removes the key and associated value from the symbol table, if present.
+// Returns the removed value and whether the key was there.
+func (t *BST) Delete(key int) (val int, found bool) {
+	t.Compute(key, func(old int, ok bool) (int, bool) {
+		val, found = old, ok
+		return 0, false
+	})
+	return val, found
+}
+
+// Same as Delete, under the name every backend shares
+func (t *BST) Remove(key int) (val int, ok bool) {
+	return t.Delete(key)
+}
+
+// Removes the smallest key and returns it with its value; false if the tree is empty
+func (t *BST) PopMin() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	minNode := t.findMin(t.root)
+	t.root = t.deleteMin(t.root)
+	return minNode.key, minNode.val, true
+}
+
+// Removes the largest key and returns it with its value; false if the tree is empty
+func (t *BST) PopMax() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	maxNode := t.findMax(t.root)
+	t.root = t.deleteMax(t.root)
+	return maxNode.key, maxNode.val, true
+}
+
+// Removes the keys in [lo, hi] from the subtree, adding their number to removed
+func (t *BST) deleteRange(node *Node, lo int, hi int, removed *int) *Node {
+	if node == nil {
+		return nil
+	}
+	t.visit(node)
+	if t.compare(node.key, lo) < 0 {
+		node.right = t.deleteRange(node.right, lo, hi, removed)
+	} else if t.compare(node.key, hi) > 0 {
+		node.left = t.deleteRange(node.left, lo, hi, removed)
+	} else {
+		left := t.deleteRange(node.left, lo, hi, removed)
+		right := t.deleteRange(node.right, lo, hi, removed)
+		*removed++
+		// what is left below the node lies outside the range: left below lo
+		// and right above hi. The successor of the node takes its place.
+		if left == nil {
+			return right
+		} else if right == nil {
+			return left
+		}
+		minNode := t.findMin(right)
+		minNode.right = t.deleteMin(right)
+		minNode.left = left
+		node = minNode
+	}
+	node.size = 1 + t.size(node.left) + t.size(node.right) // dont forget update
+	return node
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// O(h + k) for k removed keys: only the topmost removed node can have
+// survivors on both sides.
+func (t *BST) DeleteRange(lo int, hi int) int {
+	if t.compare(lo, hi) > 0 {
+		return 0
+	}
+	removed := 0
+	t.root = t.deleteRange(t.root, lo, hi, &removed)
+	return removed
 }
 
 func (t *BST) Min() (key int, val int) {
@@ -216,9 +426,11 @@ func (t *BST) floor(node *Node, key int) *Node {
 		return node
 	}
 
-	if node.key == key {
+	t.visit(node)
+	cmp := t.compare(key, node.key)
+	if cmp == 0 {
 		return node
-	} else if node.key < key {
+	} else if cmp > 0 {
 		// pay attention to this part!!
 		// if node.right != nil {return t.floor(node.right, key)}
 		//  maybe the right tree are all ndoes > key
@@ -244,9 +456,11 @@ func (t *BST) ceiling(node *Node, key int) *Node {
 		return node
 	}
 
-	if node.key == key {
+	t.visit(node)
+	cmp := t.compare(key, node.key)
+	if cmp == 0 {
 		return node
-	} else if node.key > key {
+	} else if cmp < 0 {
 		// pay attention to this part!!
 		// if node.right != nil {return t.floor(node.right, key)}
 		//  maybe the right tree are all ndoes > key
@@ -270,6 +484,7 @@ func (t *BST) selectHelper(node *Node, k int) *Node {
 	if node == nil {
 		return node
 	}
+	t.visit(node)
 	if t.size(node.left) == k { // say k = 0, should return the smallest
 		return node
 	} else if t.size(node.left) < k {
@@ -292,9 +507,11 @@ func (t *BST) rank(node *Node, key int) int {
 	if node == nil {
 		return 0
 	}
-	if node.key < key {
+	t.visit(node)
+	cmp := t.compare(key, node.key)
+	if cmp > 0 {
 		return t.size(node.left) + 1 + t.rank(node.right, key)
-	} else if node.key == key {
+	} else if cmp == 0 {
 		return t.size(node.left)
 	} else {
 		return t.rank(node.left, key)
@@ -327,9 +544,10 @@ func (t *BST) rangekeys(node *Node, lo int, hi int, res *[]int) {
 	if node == nil {
 		return
 	}
-	if node.key < lo {
+	t.visit(node)
+	if t.compare(lo, node.key) > 0 {
 		t.rangekeys(node.right, lo, hi, res)
-	} else if node.key > hi {
+	} else if t.compare(hi, node.key) < 0 {
 		t.rangekeys(node.left, lo, hi, res)
 	} else {
 		t.rangekeys(node.left, lo, hi, res)
@@ -352,22 +570,124 @@ func (t *BST) RangeSize(lo int, hi int) int {
 
 // Returns the keys in the BST in level order
 func (t *BST) LevelOrder() []int {
-	queue := make([]*Node, 0)
-	res := make([]int, 0)
-	if t.root != nil {
-		queue = append(queue, t.root)
-	}
-	for len(queue) != 0 {
-		a := queue[0]
-		res = append(res, a.key)
-		queue = queue[1:]
-		for _, element := range []*Node{a.left, a.right} {
-			if element != nil {
-				queue = append(queue, element)
-			}
+	return traversal.LevelOrder(t.root)
+}
+
+// Returns the keys in the BST grouped by depth, root level first
+func (t *BST) Levels() [][]int {
+	return traversal.Levels(t.root)
+}
+
+// Returns the keys in the BST level by level, alternating direction per level
+func (t *BST) ZigZag() [][]int {
+	return traversal.ZigZag(t.root)
+}
+
+// Returns the keys in the BST in pre-order (node, left, right)
+func (t *BST) PreOrder() []int {
+	return traversal.PreOrder(t.root)
+}
+
+// Returns the keys in the BST in in-order (left, node, right)
+func (t *BST) InOrder() []int {
+	return traversal.InOrder(t.root)
+}
+
+// Returns the keys in the BST in post-order (left, right, node)
+func (t *BST) PostOrder() []int {
+	return traversal.PostOrder(t.root)
+}
+
+// Returns the keys in the BST in in-order using O(1) extra space
+func (t *BST) MorrisInOrder() []int {
+	return traversal.MorrisInOrder(t.root, setRight)
+}
+
+// Replaces the right link of the node, for the threads of MorrisInOrder
+func setRight(node *Node, right *Node) {
+	node.right = right
+}
+
+// Returns a deep copy of the tree with the same shape and sizes.
+// The copy keeps the comparator but not the observer. O(n).
+func (t *BST) Clone() *BST {
+	return &BST{root: t.clone(t.root), comparator: t.comparator}
+}
+
+func (t *BST) clone(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.left, copied.right = t.clone(node.left), t.clone(node.right)
+	return &copied
+}
+
+// Pushes node and the chain of its left children onto the stack
+func pushLeft(stack []*Node, node *Node) []*Node {
+	for ; node != nil; node = node.left {
+		stack = append(stack, node)
+	}
+	return stack
+}
+
+// Reports whether the two trees hold the same key-value pairs in the same
+// order, whatever their shapes. Both are walked in order side by side, O(n).
+func (t *BST) Equal(other *BST) bool {
+	if t.Size() != other.Size() {
+		return false
+	}
+	s1, s2 := pushLeft(nil, t.root), pushLeft(nil, other.root)
+	for len(s1) != 0 {
+		a, b := s1[len(s1)-1], s2[len(s2)-1]
+		if a.key != b.key || a.val != b.val {
+			return false
 		}
+		s1 = pushLeft(s1[:len(s1)-1], a.right)
+		s2 = pushLeft(s2[:len(s2)-1], b.right)
 	}
-	return res
+	return true
+}
+
+// Reports whether the two trees are identical node for node: the same keys
+// and values at the same places. Meant for test assertions, e.g. that a
+// Clone reproduced the tree exactly.
+func (t *BST) EqualShape(other *BST) bool {
+	return t.equalShape(t.root, other.root)
+}
+
+func (t *BST) equalShape(a *Node, b *Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.key == b.key && a.val == b.val && t.equalShape(a.left, b.left) && t.equalShape(a.right, b.right)
+}
+
+// Removes every key; the comparator and observer stay
+func (t *BST) Clear() {
+	t.root = nil
+}
+
+// Checks the invariants of the tree: symmetric order and subtree sizes
+func (t *BST) check() error {
+	return t.checkNode(t.root, nil, nil)
+}
+
+// lo and hi bound the keys allowed in the subtree, nil meaning unbounded
+func (t *BST) checkNode(node *Node, lo *int, hi *int) error {
+	if node == nil {
+		return nil
+	}
+	if (lo != nil && t.compare(node.key, *lo) <= 0) || (hi != nil && t.compare(node.key, *hi) >= 0) {
+		return fmt.Errorf("key %d is out of order", node.key)
+	}
+	if node.size != 1+t.size(node.left)+t.size(node.right) {
+		return fmt.Errorf("node %d has size %d", node.key, node.size)
+	}
+	if err := t.checkNode(node.left, lo, &node.key); err != nil {
+		return err
+	}
+	return t.checkNode(node.right, &node.key, hi)
 }
 
 func main() {}
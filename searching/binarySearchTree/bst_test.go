@@ -4,6 +4,8 @@
 package binarySearchTree
 
 import (
+	"algo/searching/conformance"
+	"algo/searching/observer"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -251,6 +253,168 @@ func Test6(t *testing.T) {
 	fmt.Printf("\n")
 }
 
+func Test7(t *testing.T) {
+	var tree *BST = new(BST)
+	tree.Put(4, 3)
+	tree.Put(2, 4)
+	tree.Put(1, 3)
+	tree.Put(3, 5)
+	if !reflect.DeepEqual(tree.PreOrder(), []int{4, 2, 1, 3}) {
+		t.Error("PreOrder Wrong")
+	}
+	if !reflect.DeepEqual(tree.InOrder(), []int{1, 2, 3, 4}) {
+		t.Error("InOrder Wrong")
+	}
+	if !reflect.DeepEqual(tree.PostOrder(), []int{1, 3, 2, 4}) {
+		t.Error("PostOrder Wrong")
+	}
+	if !reflect.DeepEqual(tree.Levels(), [][]int{{4}, {2}, {1, 3}}) {
+		t.Error("Levels Wrong")
+	}
+	if !reflect.DeepEqual(tree.ZigZag(), [][]int{{4}, {2}, {1, 3}}) {
+		t.Error("ZigZag Wrong")
+	}
+	if !reflect.DeepEqual(tree.MorrisInOrder(), tree.Keys()) {
+		t.Error("MorrisInOrder Wrong")
+	}
+	if !reflect.DeepEqual(tree.PreOrder(), []int{4, 2, 1, 3}) {
+		t.Error("MorrisInOrder modified the tree")
+	}
+}
+
+func Test8(t *testing.T) {
+	var tree *BST = new(BST)
+	if tree.Stats().Height != -1 {
+		t.Error("Empty Tree Wrong")
+	}
+	for i := 1; i <= 7; i++ {
+		tree.Put(i, i)
+	}
+	s := tree.Stats()
+	// ascending inserts degenerate into a right-leaning path
+	if s.Size != 7 || s.Height != 6 || tree.Height() != 6 || s.Leaves != 1 {
+		t.Error("Shape Wrong")
+	}
+	if s.InternalPathLength != 21 || s.AvgDepth != 3 || s.MaxDepth != 6 {
+		t.Error("Path Length Wrong")
+	}
+	if !reflect.DeepEqual(s.BalanceFactors, map[int]int{0: 1, -1: 1, -2: 1, -3: 1, -4: 1, -5: 1, -6: 1}) {
+		t.Error("Balance Factors Wrong")
+	}
+	if s.LeftRotations != 0 || s.RightRotations != 0 {
+		t.Error("Rotations Wrong")
+	}
+}
+
+func Test9(t *testing.T) {
+	var tree *BST = new(BST)
+	c := new(observer.Counter)
+	tree.SetObserver(c)
+	for i := 1; i <= 100; i++ {
+		tree.Put(i, i)
+	}
+	// ascending inserts build a path: the i-th put compares against i-1 keys
+	if c.Allocations != 100 || c.Comparisons != 99*100/2 || c.Rotations() != 0 {
+		t.Error("Put Counters Wrong " + strconv.Itoa(c.Comparisons))
+	}
+	c.Reset()
+	tree.Get(100)
+	if c.Visits != 100 || c.Comparisons != 100 {
+		t.Error("Get Counters Wrong " + strconv.Itoa(c.Visits))
+	}
+	tree.SetObserver(nil)
+	tree.Put(0, 0)
+	if c.Allocations != 0 {
+		t.Error("Observer Not Removed")
+	}
+}
+
+func Test10(t *testing.T) {
+	var tree *BST = new(BST)
+	// count words, dropping a word whose count falls to zero
+	for _, w := range []int{3, 1, 3, 2, 3, 1} {
+		tree.Compute(w, func(old int, ok bool) (int, bool) { return old + 1, true })
+	}
+	tree.Compute(2, func(old int, ok bool) (int, bool) { return old - 1, old > 1 })
+	if tree.Size() != 2 || tree.Get(3) != 3 || tree.Get(1) != 2 || tree.Contains(2) {
+		t.Error("Compute Wrong")
+	}
+	if v, loaded := tree.GetOrPut(3, 9); v != 3 || !loaded {
+		t.Error("GetOrPut Wrong")
+	}
+	if v, loaded := tree.GetOrPut(5, 9); v != 9 || loaded || tree.Get(5) != 9 {
+		t.Error("GetOrPut Wrong")
+	}
+	if v, loaded := tree.Swap(5, 0); v != 9 || !loaded || !tree.Contains(5) {
+		t.Error("Swap Wrong")
+	}
+	if v, found := tree.Delete(5); v != 0 || !found || tree.Contains(5) {
+		t.Error("Delete Wrong")
+	}
+	if _, found := tree.Delete(5); found || tree.check() != nil {
+		t.Error("Delete Wrong")
+	}
+	// a Compute looks the key up in a single descent
+	for i := 10; i < 1000; i++ {
+		tree.Put(i, i)
+	}
+	c := new(observer.Counter)
+	tree.SetObserver(c)
+	tree.Compute(500, func(old int, ok bool) (int, bool) { return old + 1, true })
+	if c.Comparisons > tree.Height()+1 || tree.Get(500) != 501 {
+		t.Error("Compute Counters Wrong " + strconv.Itoa(c.Comparisons))
+	}
+}
+
+func Test11(t *testing.T) {
+	var tree *BST = new(BST)
+	for _, k := range []int{5, 2, 8, 1, 3, 9} {
+		tree.Put(k, k*10)
+	}
+	c := tree.Clone()
+	if !c.EqualShape(tree) || !c.Equal(tree) || c.Size() != tree.Size() || c.Height() != tree.Height() || c.check() != nil {
+		t.Error("Clone Wrong")
+	}
+	// the copy shares no nodes with the original
+	c.Put(5, 0)
+	c.Put(4, 40)
+	if tree.Get(5) != 50 || tree.Contains(4) || c.Equal(tree) {
+		t.Error("Clone Wrong")
+	}
+	// the same pairs put in another order give another shape
+	other := new(BST)
+	for _, k := range []int{9, 8, 5, 3, 2, 1} {
+		other.Put(k, k*10)
+	}
+	if !other.Equal(tree) || other.EqualShape(tree) {
+		t.Error("Equal Wrong")
+	}
+	other.Put(9, 0)
+	if other.Equal(tree) {
+		t.Error("Equal Wrong")
+	}
+	tree.Clear()
+	if tree.Size() != 0 || c.Size() != 7 || !tree.Equal(new(BST)) {
+		t.Error("Clear Wrong")
+	}
+	tree.Put(1, 1)
+	if tree.Size() != 1 || tree.check() != nil {
+		t.Error("Clear Wrong")
+	}
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapt[*Node](func() *BST { return new(BST) }, (*BST).check))
+}
+
+func TestReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Node](func() *BST { return NewWithComparator(conformance.Descending) }, (*BST).check))
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Node](func() *BST { return new(BST) }, (*BST).check))
+}
+
 /* An example of using the errors package
 func (t* BST) get(n *Node, key int) (val int, err error) {
 	if n == nil {
@@ -0,0 +1,157 @@
+package conformance
+
+// A node or entry of a backend, as returned by its Floor, Ceiling and
+// Select; the nil pointer stands for no key
+type Keyed interface {
+	comparable
+	Key() int
+}
+
+// The unordered API of a backend. Delete and Check are left out: some
+// backends return the removed value from Delete, and the invariant checks
+// are unexported.
+type Backend interface {
+	Size() int
+	Contains(key int) bool
+	Get(key int) int
+	Put(key int, val int)
+	Remove(key int) (int, bool)
+	Keys() []int
+}
+
+// The ordered API of a backend T whose Floor, Ceiling and Select return N
+type OrderedBackend[N Keyed, T any] interface {
+	Backend
+	DeleteMin()
+	DeleteMax()
+	PopMin() (key int, val int, ok bool)
+	PopMax() (key int, val int, ok bool)
+	DeleteRange(lo int, hi int) int
+	Min() (key int, val int)
+	Max() (key int, val int)
+	Floor(key int) N
+	Ceiling(key int) N
+	Select(k int) N
+	Rank(key int) int
+	RangeKeys(lo int, hi int) []int
+	RangeSize(lo int, hi int) int
+	Clone() T
+	Equal(other T) bool
+	EqualShape(other T) bool
+	Clear()
+}
+
+// Returns a constructor of newST's tables as OrderedSymbolTables, for Run,
+// Fuzz and RunReversed. check is the backend's invariant check, usually a
+// method expression such as (*AVL).check, and nil checks nothing. The
+// tables implement Updater when the backend does.
+func Adapt[N Keyed, T OrderedBackend[N, T]](newST func() T, check func(T) error) func() OrderedSymbolTable {
+	return func() OrderedSymbolTable {
+		return adapt[N](newST(), check)
+	}
+}
+
+// Wraps st as an OrderedSymbolTable, an Updater too if st is one
+func adapt[N Keyed, T OrderedBackend[N, T]](st T, check func(T) error) OrderedSymbolTable {
+	a := adaptedOrdered[N, T]{adapted[T]{st, check}}
+	if up, ok := any(st).(Updater); ok {
+		return updating[N, T]{a, up}
+	}
+	return a
+}
+
+// Same as Adapt, for unordered tables
+func AdaptUnordered[T Backend](newST func() T, check func(T) error) func() SymbolTable {
+	return func() SymbolTable {
+		return adapted[T]{newST(), check}
+	}
+}
+
+// A backend as a SymbolTable
+type adapted[T Backend] struct {
+	st    T
+	check func(T) error
+}
+
+func (a adapted[T]) Size() int                  { return a.st.Size() }
+func (a adapted[T]) Contains(key int) bool      { return a.st.Contains(key) }
+func (a adapted[T]) Get(key int) int            { return a.st.Get(key) }
+func (a adapted[T]) Put(key int, val int)       { a.st.Put(key, val) }
+func (a adapted[T]) Remove(key int) (int, bool) { return a.st.Remove(key) }
+func (a adapted[T]) Keys() []int                { return a.st.Keys() }
+
+// Delete drops whatever the backend's Delete returns
+func (a adapted[T]) Delete(key int) {
+	switch st := any(a.st).(type) {
+	case interface{ Delete(key int) }:
+		st.Delete(key)
+	case interface{ Delete(key int) (int, bool) }:
+		st.Delete(key)
+	default:
+		panic("conformance: the backend has no Delete")
+	}
+}
+
+func (a adapted[T]) Check() error {
+	if a.check == nil {
+		return nil
+	}
+	return a.check(a.st)
+}
+
+// An ordered backend as an OrderedSymbolTable
+type adaptedOrdered[N Keyed, T OrderedBackend[N, T]] struct {
+	adapted[T]
+}
+
+// Returns the backend under st, which must have been adapted from a T too
+func backend[N Keyed, T OrderedBackend[N, T]](st OrderedSymbolTable) T {
+	switch st := st.(type) {
+	case adaptedOrdered[N, T]:
+		return st.st
+	case updating[N, T]:
+		return st.st
+	}
+	panic("conformance: tables adapted from different backends")
+}
+
+// Returns the key of n and whether there is one
+func key[N Keyed](n N) (int, bool) {
+	var none N
+	if n == none {
+		return 0, false
+	}
+	return n.Key(), true
+}
+
+func (a adaptedOrdered[N, T]) DeleteMin()                     { a.st.DeleteMin() }
+func (a adaptedOrdered[N, T]) DeleteMax()                     { a.st.DeleteMax() }
+func (a adaptedOrdered[N, T]) DeleteRange(lo int, hi int) int { return a.st.DeleteRange(lo, hi) }
+func (a adaptedOrdered[N, T]) Rank(key int) int               { return a.st.Rank(key) }
+func (a adaptedOrdered[N, T]) RangeKeys(lo int, hi int) []int { return a.st.RangeKeys(lo, hi) }
+func (a adaptedOrdered[N, T]) RangeSize(lo int, hi int) int   { return a.st.RangeSize(lo, hi) }
+func (a adaptedOrdered[N, T]) Floor(k int) (int, bool)        { return key(a.st.Floor(k)) }
+func (a adaptedOrdered[N, T]) Ceiling(k int) (int, bool)      { return key(a.st.Ceiling(k)) }
+func (a adaptedOrdered[N, T]) Select(k int) (int, bool)       { return key(a.st.Select(k)) }
+
+func (a adaptedOrdered[N, T]) PopMin() (key int, val int, ok bool) { return a.st.PopMin() }
+func (a adaptedOrdered[N, T]) PopMax() (key int, val int, ok bool) { return a.st.PopMax() }
+func (a adaptedOrdered[N, T]) Min() (key int, val int)             { return a.st.Min() }
+func (a adaptedOrdered[N, T]) Max() (key int, val int)             { return a.st.Max() }
+
+func (a adaptedOrdered[N, T]) Clone() OrderedSymbolTable { return adapt[N](a.st.Clone(), a.check) }
+func (a adaptedOrdered[N, T]) Clear()                    { a.st.Clear() }
+
+func (a adaptedOrdered[N, T]) Equal(other OrderedSymbolTable) bool {
+	return a.st.Equal(backend[N, T](other))
+}
+
+func (a adaptedOrdered[N, T]) EqualShape(other OrderedSymbolTable) bool {
+	return a.st.EqualShape(backend[N, T](other))
+}
+
+// An adapted backend that implements Updater, which Apply then drives too
+type updating[N Keyed, T OrderedBackend[N, T]] struct {
+	adaptedOrdered[N, T]
+	Updater
+}
@@ -0,0 +1,700 @@
+// Shared conformance tests for the symbol tables in this repository.
+// Every backend is driven through the same random operation sequences and
+// compared against Reference, a plain map whose ordered operations sort the
+// keys on demand. After every operation the backend's own invariant check
+// runs as well, so a corrupt tree is caught where it goes wrong.
+//
+// Backends are turned into OrderedSymbolTables by Adapt in their test files,
+// which call Run from a Test function and Fuzz from a Fuzz function.
+// Backends that take a comparator also call RunReversed, and tables
+// implementing Updater have Compute, GetOrPut and Swap checked too.
+// Unordered tables go through AdaptUnordered, RunUnordered and
+// FuzzUnordered. Removals are checked through Delete, Remove, PopMin, PopMax
+// and DeleteRange alike, and Clone, Equal, EqualShape and Clear on a copy
+// of the table.
+package conformance
+
+import (
+	"cmp"
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// The unordered subset of the symbol-table API
+type SymbolTable interface {
+	Size() int
+	Contains(key int) bool
+	Get(key int) int // 0 if the key does not exist
+	Put(key int, val int)
+	Delete(key int)
+	// Removes the key and returns its value and whether it was there
+	Remove(key int) (int, bool)
+	Keys() []int // in any order for unordered tables
+	// Returns an error describing the first broken invariant, if any
+	Check() error
+}
+
+// The ordered symbol-table API. Floor, Ceiling and Select return the key
+// and whether there is one, since each backend has its own node type.
+type OrderedSymbolTable interface {
+	SymbolTable
+	DeleteMin()
+	DeleteMax()
+	// Remove the smallest or largest key and return it with its value,
+	// false for an empty table
+	PopMin() (key int, val int, ok bool)
+	PopMax() (key int, val int, ok bool)
+	// Removes every key in [lo, hi] and returns how many there were
+	DeleteRange(lo int, hi int) int
+	Min() (key int, val int)
+	Max() (key int, val int)
+	Floor(key int) (int, bool)
+	Ceiling(key int) (int, bool)
+	Select(k int) (int, bool)
+	Rank(key int) int
+	RangeKeys(lo int, hi int) []int
+	RangeSize(lo int, hi int) int
+	// Returns a copy sharing no storage with the table
+	Clone() OrderedSymbolTable
+	// Compare with a table of the same backend: the same pairs, and for
+	// EqualShape the same layout too
+	Equal(other OrderedSymbolTable) bool
+	EqualShape(other OrderedSymbolTable) bool
+	// Removes every key
+	Clear()
+}
+
+// The value-update API; Apply drives it as well on tables that implement it
+type Updater interface {
+	Compute(key int, f func(old int, ok bool) (val int, keep bool))
+	GetOrPut(key int, val int) (actual int, loaded bool)
+	Swap(key int, val int) (previous int, loaded bool)
+}
+
+// Reference is the model every backend is compared against
+type Reference struct {
+	m map[int]int
+}
+
+func NewReference() *Reference {
+	return &Reference{m: make(map[int]int)}
+}
+
+func (r *Reference) Size() int {
+	return len(r.m)
+}
+
+func (r *Reference) Contains(key int) bool {
+	_, ok := r.m[key]
+	return ok
+}
+
+func (r *Reference) Get(key int) int {
+	return r.m[key]
+}
+
+func (r *Reference) Put(key int, val int) {
+	r.m[key] = val
+}
+
+func (r *Reference) Delete(key int) {
+	delete(r.m, key)
+}
+
+func (r *Reference) Remove(key int) (int, bool) {
+	val, ok := r.m[key]
+	delete(r.m, key)
+	return val, ok
+}
+
+func (r *Reference) Compute(key int, f func(old int, ok bool) (val int, keep bool)) {
+	old, ok := r.m[key]
+	if val, keep := f(old, ok); keep {
+		r.m[key] = val
+	} else {
+		delete(r.m, key)
+	}
+}
+
+func (r *Reference) GetOrPut(key int, val int) (actual int, loaded bool) {
+	if old, ok := r.m[key]; ok {
+		return old, true
+	}
+	r.m[key] = val
+	return val, false
+}
+
+func (r *Reference) Swap(key int, val int) (previous int, loaded bool) {
+	previous, loaded = r.m[key]
+	r.m[key] = val
+	return previous, loaded
+}
+
+// Returns all keys in ascending order
+func (r *Reference) Keys() []int {
+	var res []int
+	for key := range r.m {
+		res = append(res, key)
+	}
+	sort.Ints(res)
+	return res
+}
+
+func (r *Reference) DeleteMin() {
+	if keys := r.Keys(); len(keys) > 0 {
+		delete(r.m, keys[0])
+	}
+}
+
+func (r *Reference) DeleteMax() {
+	if keys := r.Keys(); len(keys) > 0 {
+		delete(r.m, keys[len(keys)-1])
+	}
+}
+
+func (r *Reference) PopMin() (key int, val int, ok bool) {
+	if r.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = r.Min()
+	delete(r.m, key)
+	return key, val, true
+}
+
+func (r *Reference) PopMax() (key int, val int, ok bool) {
+	if r.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = r.Max()
+	delete(r.m, key)
+	return key, val, true
+}
+
+func (r *Reference) DeleteRange(lo int, hi int) int {
+	keys := r.RangeKeys(lo, hi)
+	for _, k := range keys {
+		delete(r.m, k)
+	}
+	return len(keys)
+}
+
+func (r *Reference) Min() (key int, val int) {
+	key = r.Keys()[0]
+	return key, r.m[key]
+}
+
+func (r *Reference) Max() (key int, val int) {
+	keys := r.Keys()
+	key = keys[len(keys)-1]
+	return key, r.m[key]
+}
+
+func (r *Reference) Floor(key int) (int, bool) {
+	res, ok := 0, false
+	for _, k := range r.Keys() {
+		if k <= key {
+			res, ok = k, true
+		}
+	}
+	return res, ok
+}
+
+func (r *Reference) Ceiling(key int) (int, bool) {
+	for _, k := range r.Keys() {
+		if k >= key {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+func (r *Reference) Select(k int) (int, bool) {
+	keys := r.Keys()
+	if k < 0 || k >= len(keys) {
+		return 0, false
+	}
+	return keys[k], true
+}
+
+func (r *Reference) Rank(key int) int {
+	res := 0
+	for k := range r.m {
+		if k < key {
+			res++
+		}
+	}
+	return res
+}
+
+func (r *Reference) RangeKeys(lo int, hi int) []int {
+	var res []int
+	for _, k := range r.Keys() {
+		if lo <= k && k <= hi {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+func (r *Reference) RangeSize(lo int, hi int) int {
+	return len(r.RangeKeys(lo, hi))
+}
+
+// Returns a copy with its own map
+func (r *Reference) Clone() OrderedSymbolTable {
+	return &Reference{m: maps.Clone(r.m)}
+}
+
+// Reports whether other, a Reference too, holds the same pairs
+func (r *Reference) Equal(other OrderedSymbolTable) bool {
+	return maps.Equal(r.m, other.(*Reference).m)
+}
+
+// Same as Equal: a map has no layout to compare
+func (r *Reference) EqualShape(other OrderedSymbolTable) bool {
+	return r.Equal(other)
+}
+
+func (r *Reference) Clear() {
+	clear(r.m)
+}
+
+func (r *Reference) Check() error {
+	return nil
+}
+
+// Every operation is encoded in three bytes: an opcode and two operands.
+// Operands are folded into a small key space so that keys collide often.
+const (
+	opPut = iota
+	opDelete
+	opDeleteMin
+	opDeleteMax
+	opGet
+	opFloor
+	opCeiling
+	opSelect
+	opRank
+	opRange
+	opMinMax
+	opCompute
+	opGetOrPut
+	opSwap
+	opRemove
+	opPopMin
+	opPopMax
+	opDeleteRange
+	opClone
+	opCount
+)
+
+const keySpace = 64
+
+func operand(b byte) int {
+	return int(b)%keySpace - keySpace/4
+}
+
+// Applies the encoded operations to st and to a Reference,
+// failing t as soon as the two disagree or st breaks an invariant
+func Apply(t *testing.T, st OrderedSymbolTable, ops []byte) {
+	t.Helper()
+	ref := NewReference()
+	for i := 0; i+2 < len(ops); i += 3 {
+		op, a, b := int(ops[i])%opCount, operand(ops[i+1]), operand(ops[i+2])
+		switch op {
+		case opPut:
+			st.Put(a, b)
+			ref.Put(a, b)
+		case opDelete:
+			st.Delete(a)
+			ref.Delete(a)
+		case opDeleteMin:
+			st.DeleteMin()
+			ref.DeleteMin()
+		case opDeleteMax:
+			st.DeleteMax()
+			ref.DeleteMax()
+		case opGet:
+			if st.Get(a) != ref.Get(a) || st.Contains(a) != ref.Contains(a) {
+				t.Fatalf("op %d: Get(%d) = %d, want %d", i/3, a, st.Get(a), ref.Get(a))
+			}
+		case opFloor:
+			k1, ok1 := st.Floor(a)
+			k2, ok2 := ref.Floor(a)
+			if k1 != k2 || ok1 != ok2 {
+				t.Fatalf("op %d: Floor(%d) = %d %v, want %d %v", i/3, a, k1, ok1, k2, ok2)
+			}
+		case opCeiling:
+			k1, ok1 := st.Ceiling(a)
+			k2, ok2 := ref.Ceiling(a)
+			if k1 != k2 || ok1 != ok2 {
+				t.Fatalf("op %d: Ceiling(%d) = %d %v, want %d %v", i/3, a, k1, ok1, k2, ok2)
+			}
+		case opSelect:
+			k := int(ops[i+1]) % (ref.Size() + 1)
+			k1, ok1 := st.Select(k)
+			k2, ok2 := ref.Select(k)
+			if k1 != k2 || ok1 != ok2 {
+				t.Fatalf("op %d: Select(%d) = %d %v, want %d %v", i/3, k, k1, ok1, k2, ok2)
+			}
+		case opRank:
+			if st.Rank(a) != ref.Rank(a) {
+				t.Fatalf("op %d: Rank(%d) = %d, want %d", i/3, a, st.Rank(a), ref.Rank(a))
+			}
+		case opRange:
+			lo, hi := min(a, b), max(a, b)
+			if !sameKeys(st.RangeKeys(lo, hi), ref.RangeKeys(lo, hi)) {
+				t.Fatalf("op %d: RangeKeys(%d, %d) = %v, want %v", i/3, lo, hi, st.RangeKeys(lo, hi), ref.RangeKeys(lo, hi))
+			}
+			if st.RangeSize(lo, hi) != ref.RangeSize(lo, hi) {
+				t.Fatalf("op %d: RangeSize(%d, %d) = %d, want %d", i/3, lo, hi, st.RangeSize(lo, hi), ref.RangeSize(lo, hi))
+			}
+		case opCompute, opGetOrPut, opSwap:
+			if up, ok := st.(Updater); ok {
+				applyUpdate(t, i/3, op, up, ref, a, b)
+			}
+		case opRemove:
+			v1, ok1 := st.Remove(a)
+			v2, ok2 := ref.Remove(a)
+			if v1 != v2 || ok1 != ok2 {
+				t.Fatalf("op %d: Remove(%d) = %d %v, want %d %v", i/3, a, v1, ok1, v2, ok2)
+			}
+		case opPopMin:
+			k1, v1, ok1 := st.PopMin()
+			k2, v2, ok2 := ref.PopMin()
+			if k1 != k2 || v1 != v2 || ok1 != ok2 {
+				t.Fatalf("op %d: PopMin() = %d %d %v, want %d %d %v", i/3, k1, v1, ok1, k2, v2, ok2)
+			}
+		case opPopMax:
+			k1, v1, ok1 := st.PopMax()
+			k2, v2, ok2 := ref.PopMax()
+			if k1 != k2 || v1 != v2 || ok1 != ok2 {
+				t.Fatalf("op %d: PopMax() = %d %d %v, want %d %d %v", i/3, k1, v1, ok1, k2, v2, ok2)
+			}
+		case opDeleteRange:
+			// short ranges, so that tables are not emptied too often
+			lo, hi := a, a+b%8
+			if n1, n2 := st.DeleteRange(lo, hi), ref.DeleteRange(lo, hi); n1 != n2 {
+				t.Fatalf("op %d: DeleteRange(%d, %d) = %d, want %d", i/3, lo, hi, n1, n2)
+			}
+		case opClone:
+			applyClone(t, i/3, st, ref, a)
+		case opMinMax:
+			if ref.Size() == 0 {
+				break
+			}
+			k1, v1 := st.Min()
+			k2, v2 := ref.Min()
+			if k1 != k2 || v1 != v2 {
+				t.Fatalf("op %d: Min() = %d %d, want %d %d", i/3, k1, v1, k2, v2)
+			}
+			k1, v1 = st.Max()
+			k2, v2 = ref.Max()
+			if k1 != k2 || v1 != v2 {
+				t.Fatalf("op %d: Max() = %d %d, want %d %d", i/3, k1, v1, k2, v2)
+			}
+		}
+		if st.Size() != ref.Size() {
+			t.Fatalf("op %d: Size() = %d, want %d", i/3, st.Size(), ref.Size())
+		}
+		if err := st.Check(); err != nil {
+			t.Fatalf("op %d (%d %d %d): %v", i/3, op, a, b, err)
+		}
+	}
+	if !sameKeys(st.Keys(), ref.Keys()) {
+		t.Fatalf("Keys() = %v, want %v", st.Keys(), ref.Keys())
+	}
+}
+
+// Applies one value-update operation to up and to ref
+func applyUpdate(t *testing.T, step int, op int, up Updater, ref *Reference, a int, b int) {
+	t.Helper()
+	switch op {
+	case opCompute:
+		// add b to the value, dropping the key when the sum is a multiple
+		// of 3, or put b if it is even
+		f := func(calls *[]int) func(old int, ok bool) (int, bool) {
+			return func(old int, ok bool) (int, bool) {
+				*calls = append(*calls, old)
+				if ok {
+					return old + b, (old+b)%3 != 0
+				}
+				return b, b%2 == 0
+			}
+		}
+		var got, want []int
+		up.Compute(a, f(&got))
+		ref.Compute(a, f(&want))
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("op %d: Compute(%d) called f with %v, want %v", step, a, got, want)
+		}
+	case opGetOrPut:
+		v1, ok1 := up.GetOrPut(a, b)
+		v2, ok2 := ref.GetOrPut(a, b)
+		if v1 != v2 || ok1 != ok2 {
+			t.Fatalf("op %d: GetOrPut(%d, %d) = %d %v, want %d %v", step, a, b, v1, ok1, v2, ok2)
+		}
+	case opSwap:
+		v1, ok1 := up.Swap(a, b)
+		v2, ok2 := ref.Swap(a, b)
+		if v1 != v2 || ok1 != ok2 {
+			t.Fatalf("op %d: Swap(%d, %d) = %d %v, want %d %v", step, a, b, v1, ok1, v2, ok2)
+		}
+	}
+}
+
+// Clones st, checks that the clone equals st and changes apart from it,
+// then clears the clone and puts the pairs of ref back in ascending order,
+// which gives the same pairs in what is likely another layout
+func applyClone(t *testing.T, step int, st OrderedSymbolTable, ref *Reference, a int) {
+	t.Helper()
+	c := st.Clone()
+	if !c.Equal(st) || !st.Equal(c) || !c.EqualShape(st) || !st.EqualShape(c) {
+		t.Fatalf("op %d: Clone() differs from the table", step)
+	}
+	if err := c.Check(); err != nil {
+		t.Fatalf("op %d: Clone(): %v", step, err)
+	}
+	c.Put(a, st.Get(a)+1)
+	if c.Equal(st) || st.Equal(c) || c.EqualShape(st) || st.Get(a) != ref.Get(a) || st.Size() != ref.Size() {
+		t.Fatalf("op %d: Put(%d) on the clone is seen by the table", step, a)
+	}
+	c.Clear()
+	if c.Size() != 0 || len(c.Keys()) != 0 || c.Contains(a) || st.Size() != ref.Size() {
+		t.Fatalf("op %d: Clear() left %d keys", step, c.Size())
+	}
+	for _, k := range ref.Keys() {
+		c.Put(k, ref.Get(k))
+	}
+	if !c.Equal(st) || !st.Equal(c) {
+		t.Fatalf("op %d: Equal() is false for the same pairs", step)
+	}
+	if err := c.Check(); err != nil {
+		t.Fatalf("op %d: Clear(): %v", step, err)
+	}
+}
+
+// Applies the encoded operations to st and to a Reference like Apply,
+// using only Put, Delete, Remove and Get
+func ApplyUnordered(t *testing.T, st SymbolTable, ops []byte) {
+	t.Helper()
+	ref := NewReference()
+	unordered := []int{opPut, opDelete, opGet, opRemove}
+	for i := 0; i+2 < len(ops); i += 3 {
+		op, a, b := unordered[int(ops[i])%len(unordered)], operand(ops[i+1]), operand(ops[i+2])
+		switch op {
+		case opPut:
+			st.Put(a, b)
+			ref.Put(a, b)
+		case opDelete:
+			st.Delete(a)
+			ref.Delete(a)
+		case opRemove:
+			v1, ok1 := st.Remove(a)
+			v2, ok2 := ref.Remove(a)
+			if v1 != v2 || ok1 != ok2 {
+				t.Fatalf("op %d: Remove(%d) = %d %v, want %d %v", i/3, a, v1, ok1, v2, ok2)
+			}
+		default:
+			if st.Get(a) != ref.Get(a) || st.Contains(a) != ref.Contains(a) {
+				t.Fatalf("op %d: Get(%d) = %d, want %d", i/3, a, st.Get(a), ref.Get(a))
+			}
+		}
+		if st.Size() != ref.Size() {
+			t.Fatalf("op %d: Size() = %d, want %d", i/3, st.Size(), ref.Size())
+		}
+		if err := st.Check(); err != nil {
+			t.Fatalf("op %d (%d %d %d): %v", i/3, op, a, b, err)
+		}
+	}
+	keys := st.Keys()
+	sort.Ints(keys)
+	if !sameKeys(keys, ref.Keys()) {
+		t.Fatalf("Keys() = %v, want %v", keys, ref.Keys())
+	}
+}
+
+// nil and empty slices are the same set of keys
+func sameKeys(a []int, b []int) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// Returns n random operations, reproducible for a given seed
+func randomOps(seed uint64, n int) []byte {
+	r := rand.New(rand.NewPCG(seed, seed))
+	ops := make([]byte, 3*n)
+	for i := range ops {
+		ops[i] = byte(r.UintN(256))
+	}
+	return ops
+}
+
+// Runs seeded random operation sequences against fresh tables
+func Run(t *testing.T, newST func() OrderedSymbolTable) {
+	for seed := uint64(0); seed < 50; seed++ {
+		Apply(t, newST(), randomOps(seed, 500))
+	}
+}
+
+// Fuzzes fresh tables with arbitrary operation sequences
+func Fuzz(f *testing.F, newST func() OrderedSymbolTable) {
+	for seed := uint64(0); seed < 4; seed++ {
+		f.Add(randomOps(seed, 100))
+	}
+	// ascending puts followed by deletes from both ends
+	var ops []byte
+	for k := byte(0); k < keySpace; k++ {
+		ops = append(ops, opPut, k, k)
+	}
+	for k := byte(0); k < keySpace/2; k++ {
+		ops = append(ops, opDeleteMin, 0, 0, opDeleteMax, 0, 0, opSelect, k, 0)
+	}
+	f.Add(ops)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		Apply(t, newST(), ops)
+	})
+}
+
+// Runs seeded random Put/Delete/Remove/Get sequences against fresh tables
+func RunUnordered(t *testing.T, newST func() SymbolTable) {
+	for seed := uint64(0); seed < 50; seed++ {
+		ApplyUnordered(t, newST(), randomOps(seed, 500))
+	}
+}
+
+// Fuzzes fresh unordered tables with arbitrary operation sequences
+func FuzzUnordered(f *testing.F, newST func() SymbolTable) {
+	for seed := uint64(0); seed < 4; seed++ {
+		f.Add(randomOps(seed, 100))
+	}
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		ApplyUnordered(t, newST(), ops)
+	})
+}
+
+// Orders ints from largest to smallest, for backends built with a comparator
+func Descending(a int, b int) int {
+	return cmp.Compare(b, a)
+}
+
+// negated adapts a table ordered by Descending to the ascending order of
+// Reference: a key k is stored as -k, so the table's first key is the
+// smallest k
+type negated struct {
+	st OrderedSymbolTable
+}
+
+func negate(keys []int) []int {
+	for i := range keys {
+		keys[i] = -keys[i]
+	}
+	return keys
+}
+
+func (n negated) Size() int                      { return n.st.Size() }
+func (n negated) Contains(key int) bool          { return n.st.Contains(-key) }
+func (n negated) Get(key int) int                { return n.st.Get(-key) }
+func (n negated) Put(key int, val int)           { n.st.Put(-key, val) }
+func (n negated) Delete(key int)                 { n.st.Delete(-key) }
+func (n negated) Keys() []int                    { return negate(n.st.Keys()) }
+func (n negated) Check() error                   { return n.st.Check() }
+func (n negated) DeleteMin()                     { n.st.DeleteMin() }
+func (n negated) DeleteMax()                     { n.st.DeleteMax() }
+func (n negated) Rank(key int) int               { return n.st.Rank(-key) }
+func (n negated) Remove(key int) (int, bool)     { return n.st.Remove(-key) }
+func (n negated) DeleteRange(lo int, hi int) int { return n.st.DeleteRange(-lo, -hi) }
+func (n negated) RangeSize(lo int, hi int) int   { return n.st.RangeSize(-lo, -hi) }
+func (n negated) Clone() OrderedSymbolTable      { return negated{n.st.Clone()} }
+func (n negated) Clear()                         { n.st.Clear() }
+
+func (n negated) Equal(other OrderedSymbolTable) bool {
+	return n.st.Equal(other.(negated).st)
+}
+
+func (n negated) EqualShape(other OrderedSymbolTable) bool {
+	return n.st.EqualShape(other.(negated).st)
+}
+
+func (n negated) RangeKeys(lo int, hi int) []int {
+	return negate(n.st.RangeKeys(-lo, -hi))
+}
+
+func (n negated) Min() (key int, val int) {
+	key, val = n.st.Min()
+	return -key, val
+}
+
+func (n negated) Max() (key int, val int) {
+	key, val = n.st.Max()
+	return -key, val
+}
+
+func (n negated) PopMin() (key int, val int, ok bool) {
+	key, val, ok = n.st.PopMin()
+	return -key, val, ok
+}
+
+func (n negated) PopMax() (key int, val int, ok bool) {
+	key, val, ok = n.st.PopMax()
+	return -key, val, ok
+}
+
+func (n negated) Floor(key int) (int, bool) {
+	k, ok := n.st.Floor(-key)
+	return -k, ok
+}
+
+func (n negated) Ceiling(key int) (int, bool) {
+	k, ok := n.st.Ceiling(-key)
+	return -k, ok
+}
+
+func (n negated) Select(k int) (int, bool) {
+	key, ok := n.st.Select(k)
+	return -key, ok
+}
+
+// Checks tables built with the Descending comparator: every ordered
+// operation follows the comparator, so Floor and Ceiling trade places and
+// ranges run from the larger key to the smaller one. Then runs Run on
+// them with every key negated.
+func RunReversed(t *testing.T, newReversed func() OrderedSymbolTable) {
+	st := newReversed()
+	for _, k := range []int{2, 8, 4, 6} {
+		st.Put(k, k*10)
+	}
+	if keys := st.Keys(); !reflect.DeepEqual(keys, []int{8, 6, 4, 2}) {
+		t.Fatalf("Keys() = %v, want [8 6 4 2]", keys)
+	}
+	if k, v := st.Min(); k != 8 || v != 80 {
+		t.Fatalf("Min() = %d %d, want 8 80", k, v)
+	}
+	if k, _ := st.Max(); k != 2 {
+		t.Fatalf("Max() = %d, want 2", k)
+	}
+	if k, ok := st.Floor(5); !ok || k != 6 {
+		t.Fatalf("Floor(5) = %d %v, want 6", k, ok)
+	}
+	if k, ok := st.Ceiling(5); !ok || k != 4 {
+		t.Fatalf("Ceiling(5) = %d %v, want 4", k, ok)
+	}
+	if _, ok := st.Floor(9); ok {
+		t.Fatal("Floor(9) found a key, want none")
+	}
+	if _, ok := st.Ceiling(1); ok {
+		t.Fatal("Ceiling(1) found a key, want none")
+	}
+	if k, ok := st.Select(1); !ok || k != 6 || st.Rank(5) != 2 {
+		t.Fatalf("Select(1) = %d, Rank(5) = %d, want 6 and 2", k, st.Rank(5))
+	}
+	if keys := st.RangeKeys(7, 3); !reflect.DeepEqual(keys, []int{6, 4}) || st.RangeSize(7, 3) != 2 || st.RangeSize(3, 7) != 0 {
+		t.Fatalf("RangeKeys(7, 3) = %v, want [6 4]", keys)
+	}
+	Run(t, func() OrderedSymbolTable { return negated{newReversed()} })
+}
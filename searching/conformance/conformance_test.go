@@ -0,0 +1,85 @@
+package conformance
+
+import "testing"
+
+func TestReference(t *testing.T) {
+	Run(t, func() OrderedSymbolTable { return NewReference() })
+}
+
+func TestReferenceUnordered(t *testing.T) {
+	RunUnordered(t, func() SymbolTable { return NewReference() })
+}
+
+func Test1(t *testing.T) {
+	r := NewReference()
+	for _, k := range []int{5, 1, 3} {
+		r.Put(k, k*10)
+	}
+	if k, ok := r.Floor(4); !ok || k != 3 {
+		t.Error("Floor Wrong")
+	}
+	if _, ok := r.Floor(0); ok {
+		t.Error("Floor Wrong")
+	}
+	if k, ok := r.Ceiling(4); !ok || k != 5 {
+		t.Error("Ceiling Wrong")
+	}
+	if k, ok := r.Select(1); !ok || k != 3 || r.Rank(3) != 1 {
+		t.Error("Select Wrong")
+	}
+	if k, v := r.Max(); k != 5 || v != 50 {
+		t.Error("Max Wrong")
+	}
+}
+
+// negating the keys twice gives back the order of Reference
+func TestNegated(t *testing.T) {
+	Run(t, func() OrderedSymbolTable { return negated{negated{NewReference()}} })
+}
+
+// An entry as a backend returns it
+type entry struct {
+	key int
+}
+
+func (e *entry) Key() int {
+	return e.key
+}
+
+// keyedReference is a Reference whose Floor, Ceiling and Select return
+// entries, like a backend's
+type keyedReference struct {
+	*Reference
+}
+
+func toEntry(key int, ok bool) *entry {
+	if !ok {
+		return nil
+	}
+	return &entry{key}
+}
+
+func (r keyedReference) Floor(key int) *entry   { return toEntry(r.Reference.Floor(key)) }
+func (r keyedReference) Ceiling(key int) *entry { return toEntry(r.Reference.Ceiling(key)) }
+func (r keyedReference) Select(k int) *entry    { return toEntry(r.Reference.Select(k)) }
+
+func (r keyedReference) Clone() keyedReference {
+	return keyedReference{r.Reference.Clone().(*Reference)}
+}
+
+func (r keyedReference) Equal(other keyedReference) bool {
+	return r.Reference.Equal(other.Reference)
+}
+
+func (r keyedReference) EqualShape(other keyedReference) bool {
+	return r.Reference.EqualShape(other.Reference)
+}
+
+func TestAdapt(t *testing.T) {
+	newST := Adapt[*entry](func() keyedReference { return keyedReference{NewReference()} }, nil)
+	if _, ok := newST().(Updater); !ok {
+		t.Error("Updater Wrong")
+	}
+	Run(t, newST)
+	RunUnordered(t, AdaptUnordered(NewReference, (*Reference).Check))
+}
@@ -0,0 +1,111 @@
+// A Fenwick tree, or binary indexed tree: prefix sums over an array with
+// point updates, both in O(log n), in a single array the size of the input.
+// Entry i of the tree holds the sum of the lowbit(i) values ending at i,
+// where lowbit(i) is the lowest set bit of i (1-indexed).
+// https://en.wikipedia.org/wiki/Fenwick_tree
+package fenwick
+
+// The value types a Fenwick tree can sum
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// The struct holds the prefix sums of an array of n values, indexed from 0.
+type Fenwick[T Number] struct {
+	tree []T // 1-indexed, tree[0] is unused
+}
+
+// Returns a Fenwick tree over n zeros
+func New[T Number](n int) *Fenwick[T] {
+	if n < 0 {
+		panic("fenwick: negative length")
+	}
+	return &Fenwick[T]{tree: make([]T, n+1)}
+}
+
+// Returns a Fenwick tree over a copy of values, built in O(n)
+func FromSlice[T Number](values []T) *Fenwick[T] {
+	f := New[T](len(values))
+	copy(f.tree[1:], values)
+	for i := 1; i < len(f.tree); i++ {
+		// push every partial sum up to the next entry that covers it
+		if j := i + i&-i; j < len(f.tree) {
+			f.tree[j] += f.tree[i]
+		}
+	}
+	return f
+}
+
+// Returns the number of values
+func (f *Fenwick[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+func (f *Fenwick[T]) checkIndex(i int) {
+	if i < 0 || i >= f.Len() {
+		panic("fenwick: index out of range")
+	}
+}
+
+// Adds delta to the value at index i
+func (f *Fenwick[T]) Add(i int, delta T) {
+	f.checkIndex(i)
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// Returns the sum of the first n values, those at indices [0, n)
+func (f *Fenwick[T]) PrefixSum(n int) T {
+	if n < 0 || n > f.Len() {
+		panic("fenwick: index out of range")
+	}
+	var res T
+	for ; n > 0; n -= n & -n {
+		res += f.tree[n]
+	}
+	return res
+}
+
+// Returns the sum of the values at indices [lo, hi)
+func (f *Fenwick[T]) RangeSum(lo int, hi int) T {
+	if lo >= hi {
+		var zero T
+		return zero
+	}
+	return f.PrefixSum(hi) - f.PrefixSum(lo)
+}
+
+// Returns the value at index i
+func (f *Fenwick[T]) Get(i int) T {
+	f.checkIndex(i)
+	return f.RangeSum(i, i+1)
+}
+
+// Sets the value at index i
+func (f *Fenwick[T]) Set(i int, val T) {
+	f.Add(i, val-f.Get(i))
+}
+
+// Returns the smallest index i such that the values at [0, i] sum to at
+// least target, or Len() if they never do. The values must be non-negative,
+// so that prefix sums never decrease. O(log n), walking down the implicit
+// tree instead of binary searching over PrefixSum.
+func (f *Fenwick[T]) LowerBound(target T) int {
+	pos := 0
+	var sum T
+	step := 1
+	for step*2 < len(f.tree) {
+		step *= 2
+	}
+	for ; step > 0; step /= 2 {
+		// extend the prefix while it stays below target
+		if next := pos + step; next < len(f.tree) && sum+f.tree[next] < target {
+			pos = next
+			sum += f.tree[next]
+		}
+	}
+	return pos
+}
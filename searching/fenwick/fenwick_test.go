@@ -0,0 +1,83 @@
+package fenwick
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	f := FromSlice([]int{3, 0, 2, 5, 1})
+	if f.Len() != 5 || f.PrefixSum(0) != 0 || f.PrefixSum(5) != 11 || f.RangeSum(1, 4) != 7 || f.Get(3) != 5 {
+		t.Error("Sum Wrong")
+	}
+	f.Add(1, 4)
+	f.Set(4, 10)
+	if f.PrefixSum(2) != 7 || f.PrefixSum(5) != 24 || f.Get(4) != 10 {
+		t.Error("Update Wrong")
+	}
+	// prefix sums are 3 7 9 14 24
+	for target, want := range []int{0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 3} {
+		if f.LowerBound(target) != want {
+			t.Errorf("LowerBound(%d) = %d, want %d", target, f.LowerBound(target), want)
+		}
+	}
+	if f.LowerBound(24) != 4 || f.LowerBound(25) != 5 {
+		t.Error("LowerBound Past The End Wrong")
+	}
+	if New[float64](0).LowerBound(1) != 0 {
+		t.Error("Empty LowerBound Wrong")
+	}
+}
+
+// every operation against a plain slice
+func Test2(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 1))
+	for n := 0; n < 40; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = r.IntN(10)
+		}
+		f := FromSlice(values)
+		for op := 0; op < 200 && n > 0; op++ {
+			i := r.IntN(n)
+			if op%2 == 0 {
+				delta := r.IntN(10)
+				f.Add(i, delta)
+				values[i] += delta
+			} else {
+				f.Set(i, r.IntN(10))
+				values[i] = f.Get(i)
+			}
+			lo := r.IntN(n + 1)
+			hi := lo + r.IntN(n+1-lo)
+			sum := 0
+			for _, v := range values[lo:hi] {
+				sum += v
+			}
+			if f.RangeSum(lo, hi) != sum {
+				t.Fatalf("n %d: RangeSum(%d, %d) = %d, want %d", n, lo, hi, f.RangeSum(lo, hi), sum)
+			}
+			target := r.IntN(10 * n)
+			want, prefix := n, 0
+			for j, v := range values {
+				prefix += v
+				if prefix >= target {
+					want = j
+					break
+				}
+			}
+			if f.LowerBound(target) != want {
+				t.Fatalf("n %d: LowerBound(%d) = %d, want %d", n, target, f.LowerBound(target), want)
+			}
+		}
+	}
+}
+
+func Test3(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Index Out Of Range Accepted")
+		}
+	}()
+	New[int](3).Add(3, 1)
+}
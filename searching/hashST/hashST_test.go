@@ -0,0 +1,118 @@
+package hashST
+
+import (
+	"algo/searching/AVLTree"
+	"algo/searching/conformance"
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+var newSeparateChainingST = conformance.AdaptUnordered(NewSeparateChainingHashST, (*SeparateChainingHashST).check)
+
+var newLinearProbingST = conformance.AdaptUnordered(NewLinearProbingHashST, (*LinearProbingHashST).check)
+
+func Test1(t *testing.T) {
+	for _, st := range []conformance.SymbolTable{newSeparateChainingST(), newLinearProbingST()} {
+		st.Put(1, 3)
+		st.Put(1, 4)
+		st.Put(2, 3)
+		st.Put(-4, 5)
+		if st.Size() != 3 || st.Get(1) != 4 || st.Get(-4) != 5 || st.Get(7) != 0 {
+			t.Error("Put Wrong")
+		}
+		st.Delete(1)
+		st.Delete(7)
+		if st.Size() != 2 || st.Contains(1) || !st.Contains(2) {
+			t.Error("Delete Wrong")
+		}
+		keys := st.Keys()
+		sort.Ints(keys)
+		if !reflect.DeepEqual(keys, []int{-4, 2}) {
+			t.Error("Keys Wrong")
+		}
+	}
+}
+
+func Test2(t *testing.T) {
+	chaining := NewSeparateChainingHashST()
+	probing := NewLinearProbingHashST()
+	for i := 0; i < 1000; i++ {
+		chaining.Put(i, i)
+		probing.Put(i, i)
+	}
+	// grown: at most 10 keys per chain, at most half of the slots used
+	if len(chaining.chains) < 100 || len(probing.keys) < 2000 {
+		t.Error("Grow Wrong " + strconv.Itoa(len(chaining.chains)) + " " + strconv.Itoa(len(probing.keys)))
+	}
+	for i := 0; i < 1000; i++ {
+		chaining.Delete(i)
+		probing.Delete(i)
+	}
+	if len(chaining.chains) > 2*initCapacity || len(probing.keys) > 2*initCapacity {
+		t.Error("Shrink Wrong")
+	}
+	if chaining.check() != nil || probing.check() != nil {
+		t.Error("Check Wrong")
+	}
+}
+
+func Test3(t *testing.T) {
+	// the zero values are usable
+	var chaining SeparateChainingHashST
+	var probing LinearProbingHashST
+	if chaining.Contains(1) || probing.Contains(1) {
+		t.Error("Empty Wrong")
+	}
+	chaining.Delete(1)
+	probing.Delete(1)
+	chaining.Put(1, 2)
+	probing.Put(1, 2)
+	if chaining.Get(1) != 2 || probing.Get(1) != 2 {
+		t.Error("Zero Value Wrong")
+	}
+}
+
+func TestConformance(t *testing.T) {
+	conformance.RunUnordered(t, newSeparateChainingST)
+	conformance.RunUnordered(t, newLinearProbingST)
+}
+
+func FuzzSeparateChaining(f *testing.F) {
+	conformance.FuzzUnordered(f, newSeparateChainingST)
+}
+
+func FuzzLinearProbing(f *testing.F) {
+	conformance.FuzzUnordered(f, newLinearProbingST)
+}
+
+// Point lookups of present keys, against AVL.Get on the same keys
+func BenchmarkGet(b *testing.B) {
+	tables := []struct {
+		name string
+		new  func() conformance.SymbolTable
+	}{
+		{"SeparateChaining", newSeparateChainingST},
+		{"LinearProbing", newLinearProbingST},
+		// the AVL tree stands in the same table, unchecked
+		{"AVL", conformance.AdaptUnordered(func() *AVLTree.AVL { return new(AVLTree.AVL) }, nil)},
+	}
+	for _, table := range tables {
+		b.Run(table.name, func(b *testing.B) {
+			for _, n := range []int{1e3, 1e4, 1e5, 1e6} {
+				keys := rand.New(rand.NewPCG(1, 2)).Perm(n)
+				st := table.new()
+				for _, key := range keys {
+					st.Put(key, key)
+				}
+				b.Run("n="+strconv.Itoa(n), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						st.Get(keys[i%n])
+					}
+				})
+			}
+		})
+	}
+}
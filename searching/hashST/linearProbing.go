@@ -0,0 +1,148 @@
+package hashST
+
+import "fmt"
+
+// The struct represents an unordered symbol table of int key-value pairs
+// stored in open-addressed parallel arrays. A key lives in the first free
+// slot at or after its hash; the table doubles when it becomes half full
+// and halves when it drops to an eighth full, so probe sequences stay short.
+type LinearProbingHashST struct {
+	n    int // number of key-value pairs
+	keys []int
+	vals []int
+	used []bool // int keys have no null, so occupancy is tracked separately
+}
+
+func NewLinearProbingHashST() *LinearProbingHashST {
+	st := new(LinearProbingHashST)
+	st.resize(initCapacity)
+	return st
+}
+
+// Rehashes every key into a table with m slots
+func (st *LinearProbingHashST) resize(m int) {
+	old := *st
+	*st = LinearProbingHashST{keys: make([]int, m), vals: make([]int, m), used: make([]bool, m)}
+	for i := range old.keys {
+		if old.used[i] {
+			st.Put(old.keys[i], old.vals[i])
+		}
+	}
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (st *LinearProbingHashST) Size() int {
+	return st.n
+}
+
+// Returns the slot holding key, -1 if there is none
+func (st *LinearProbingHashST) get(key int) int {
+	m := len(st.keys)
+	if m == 0 {
+		return -1
+	}
+	for i := hash(key, m); st.used[i]; i = (i + 1) % m {
+		if st.keys[i] == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get value by key, return 0 if not exist
+func (st *LinearProbingHashST) Get(key int) int {
+	if i := st.get(key); i != -1 {
+		return st.vals[i]
+	}
+	return 0
+}
+
+// Return true if the key exists in the symbol table
+func (st *LinearProbingHashST) Contains(key int) bool {
+	return st.get(key) != -1
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (st *LinearProbingHashST) Put(key int, val int) {
+	if len(st.keys) == 0 {
+		st.resize(initCapacity)
+	} else if st.n >= len(st.keys)/2 {
+		st.resize(2 * len(st.keys))
+	}
+	m := len(st.keys)
+	i := hash(key, m)
+	for ; st.used[i]; i = (i + 1) % m {
+		if st.keys[i] == key {
+			st.vals[i] = val
+			return
+		}
+	}
+	st.keys[i], st.vals[i], st.used[i] = key, val, true
+	st.n++
+}
+
+// Removes the key and associated value from the symbol table, if present.
+// The rest of the cluster is reinserted so that no probe sequence is cut.
+func (st *LinearProbingHashST) Delete(key int) {
+	i := st.get(key)
+	if i == -1 {
+		return
+	}
+	m := len(st.keys)
+	st.used[i] = false
+	st.n--
+	for i = (i + 1) % m; st.used[i]; i = (i + 1) % m {
+		k, v := st.keys[i], st.vals[i]
+		st.used[i] = false
+		st.n--
+		st.Put(k, v)
+	}
+	if m > initCapacity && st.n <= m/8 {
+		st.resize(m / 2)
+	}
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (st *LinearProbingHashST) Remove(key int) (val int, ok bool) {
+	i := st.get(key)
+	if i == -1 {
+		return 0, false
+	}
+	val = st.vals[i]
+	st.Delete(key)
+	return val, true
+}
+
+// Returns all keys in the symbol table, in no particular order
+func (st *LinearProbingHashST) Keys() []int {
+	var res []int
+	for i, key := range st.keys {
+		if st.used[i] {
+			res = append(res, key)
+		}
+	}
+	return res
+}
+
+// Checks that the table is at most half full and that every key is
+// reachable from its hash without crossing a free slot
+func (st *LinearProbingHashST) check() error {
+	m := len(st.keys)
+	if m > 0 && st.n > m/2 {
+		return fmt.Errorf("%d pairs in %d slots", st.n, m)
+	}
+	n := 0
+	for i, key := range st.keys {
+		if !st.used[i] {
+			continue
+		}
+		n++
+		if st.get(key) != i {
+			return fmt.Errorf("key %d in slot %d is unreachable", key, i)
+		}
+	}
+	if n != st.n {
+		return fmt.Errorf("%d pairs are counted as %d", n, st.n)
+	}
+	return nil
+}
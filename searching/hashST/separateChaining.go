@@ -0,0 +1,158 @@
+// Symbol tables implemented with hash tables.
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/SeparateChainingHashST.java.html
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/LinearProbingHashST.java.html
+package hashST
+
+import "fmt"
+
+const initCapacity = 4
+
+// Returns a well-mixed hash of key in [0, m).
+// Multiplying by the golden ratio spreads consecutive keys over the table.
+func hash(key int, m int) int {
+	h := uint64(key) * 0x9E3779B97F4A7C15
+	return int((h >> 32) % uint64(m))
+}
+
+type Node struct {
+	key  int
+	val  int
+	next *Node
+}
+
+// The struct represents an unordered symbol table of int key-value pairs.
+// Each slot holds a linked list of the keys hashing to it; the table
+// doubles when the average list length reaches 10 and halves when it
+// drops to 2, so lists stay short.
+type SeparateChainingHashST struct {
+	n      int     // number of key-value pairs
+	chains []*Node // the zero value starts without a table
+}
+
+func NewSeparateChainingHashST() *SeparateChainingHashST {
+	return &SeparateChainingHashST{chains: make([]*Node, initCapacity)}
+}
+
+// Rehashes every key into a table with m chains
+func (st *SeparateChainingHashST) resize(m int) {
+	chains := make([]*Node, m)
+	for _, node := range st.chains {
+		for node != nil {
+			next := node.next
+			i := hash(node.key, m)
+			node.next = chains[i]
+			chains[i] = node
+			node = next
+		}
+	}
+	st.chains = chains
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (st *SeparateChainingHashST) Size() int {
+	return st.n
+}
+
+// Returns the node holding key, nil if there is none
+func (st *SeparateChainingHashST) get(key int) *Node {
+	if len(st.chains) == 0 {
+		return nil
+	}
+	for node := st.chains[hash(key, len(st.chains))]; node != nil; node = node.next {
+		if node.key == key {
+			return node
+		}
+	}
+	return nil
+}
+
+// Get value by key, return 0 if not exist
+func (st *SeparateChainingHashST) Get(key int) int {
+	if node := st.get(key); node != nil {
+		return node.val
+	}
+	return 0
+}
+
+// Return true if the key exists in the symbol table
+func (st *SeparateChainingHashST) Contains(key int) bool {
+	return st.get(key) != nil
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (st *SeparateChainingHashST) Put(key int, val int) {
+	if node := st.get(key); node != nil {
+		node.val = val
+		return
+	}
+	if len(st.chains) == 0 {
+		st.chains = make([]*Node, initCapacity)
+	} else if st.n >= 10*len(st.chains) {
+		st.resize(2 * len(st.chains))
+	}
+	i := hash(key, len(st.chains))
+	st.chains[i] = &Node{key, val, st.chains[i]}
+	st.n++
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (st *SeparateChainingHashST) Delete(key int) {
+	if len(st.chains) == 0 {
+		return
+	}
+	i := hash(key, len(st.chains))
+	for link := &st.chains[i]; *link != nil; link = &(*link).next {
+		if (*link).key == key {
+			*link = (*link).next
+			st.n--
+			if len(st.chains) > initCapacity && st.n <= 2*len(st.chains) {
+				st.resize(len(st.chains) / 2)
+			}
+			return
+		}
+	}
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (st *SeparateChainingHashST) Remove(key int) (val int, ok bool) {
+	node := st.get(key)
+	if node == nil {
+		return 0, false
+	}
+	st.Delete(key)
+	return node.val, true
+}
+
+// Returns all keys in the symbol table, in no particular order
+func (st *SeparateChainingHashST) Keys() []int {
+	var res []int
+	for _, node := range st.chains {
+		for ; node != nil; node = node.next {
+			res = append(res, node.key)
+		}
+	}
+	return res
+}
+
+// Checks that every key sits in the chain it hashes to, only once,
+// and that the count of pairs is right
+func (st *SeparateChainingHashST) check() error {
+	n := 0
+	for i, node := range st.chains {
+		seen := make(map[int]bool)
+		for ; node != nil; node = node.next {
+			if hash(node.key, len(st.chains)) != i {
+				return fmt.Errorf("key %d is in chain %d", node.key, i)
+			}
+			if seen[node.key] {
+				return fmt.Errorf("key %d is stored twice", node.key)
+			}
+			seen[node.key] = true
+			n++
+		}
+	}
+	if n != st.n {
+		return fmt.Errorf("%d pairs are counted as %d", n, st.n)
+	}
+	return nil
+}
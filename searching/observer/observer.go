@@ -0,0 +1,62 @@
+// Instrumentation hooks for the search trees in `searching`.
+// A tree reports every key comparison, node visit, rotation and node
+// allocation to its Observer, which makes it possible to check the
+// O(log n) bounds of get/put/delete empirically.
+package observer
+
+// Direction of a rotation
+type Direction int
+
+const (
+	Left Direction = iota
+	Right
+)
+
+// Observer receives callbacks from a tree while it works.
+// A tree without an observer pays only a nil check per event.
+type Observer interface {
+	OnCompare(a int, b int)          // a key comparison between a and b
+	OnVisit(key int)                 // a node is looked at during a descent
+	OnRotate(dir Direction, key int) // the subtree rooted at key is rotated
+	OnAlloc(key int)                 // a new node is allocated for key
+}
+
+// Counter is an Observer that counts the events it receives,
+// handy in tests and benchmarks.
+type Counter struct {
+	Comparisons    int
+	Visits         int
+	LeftRotations  int
+	RightRotations int
+	Allocations    int
+}
+
+func (c *Counter) OnCompare(a int, b int) {
+	c.Comparisons++
+}
+
+func (c *Counter) OnVisit(key int) {
+	c.Visits++
+}
+
+func (c *Counter) OnRotate(dir Direction, key int) {
+	if dir == Left {
+		c.LeftRotations++
+	} else {
+		c.RightRotations++
+	}
+}
+
+func (c *Counter) OnAlloc(key int) {
+	c.Allocations++
+}
+
+// Returns the total number of rotations
+func (c *Counter) Rotations() int {
+	return c.LeftRotations + c.RightRotations
+}
+
+// Sets every counter back to zero
+func (c *Counter) Reset() {
+	*c = Counter{}
+}
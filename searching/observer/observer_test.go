@@ -0,0 +1,25 @@
+package observer
+
+import "testing"
+
+func Test1(t *testing.T) {
+	var o Observer = new(Counter)
+	o.OnCompare(1, 2)
+	o.OnCompare(2, 1)
+	o.OnVisit(1)
+	o.OnRotate(Left, 1)
+	o.OnRotate(Right, 2)
+	o.OnRotate(Right, 3)
+	o.OnAlloc(1)
+	c := o.(*Counter)
+	if c.Comparisons != 2 || c.Visits != 1 || c.Allocations != 1 {
+		t.Error("Counter Wrong")
+	}
+	if c.LeftRotations != 1 || c.RightRotations != 2 || c.Rotations() != 3 {
+		t.Error("Rotations Wrong")
+	}
+	c.Reset()
+	if *c != (Counter{}) {
+		t.Error("Reset Wrong")
+	}
+}
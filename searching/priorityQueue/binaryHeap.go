@@ -0,0 +1,108 @@
+// Priority queues: binary and d-ary heaps, an indexed priority queue and a
+// pairing heap. Every queue orders its items with a comparison function
+// returning a negative number, zero or a positive number like cmp.Compare,
+// and pops the smallest item first; pass a reversed comparison for a max
+// queue. MinMaxPQ and IntervalHeap are double-ended queues of int keys that
+// pop from either end.
+// https://algs4.cs.princeton.edu/24pq/
+package priorityQueue
+
+import (
+	"fmt"
+)
+
+// A binary heap in a slice: the children of the item at i are at 2i+1 and
+// 2i+2, and no item is smaller than its parent. Push and Pop take O(log n).
+type BinaryHeap[T any] struct {
+	items []T
+	cmp   func(a T, b T) int
+}
+
+// Returns an empty heap ordered by cmp, e.g. cmp.Compare[int]
+func NewBinaryHeap[T any](cmp func(a T, b T) int) *BinaryHeap[T] {
+	return &BinaryHeap[T]{cmp: cmp}
+}
+
+// Returns a heap of the items ordered by cmp, built in O(n)
+func BinaryHeapFrom[T any](items []T, cmp func(a T, b T) int) *BinaryHeap[T] {
+	h := &BinaryHeap[T]{items: append([]T(nil), items...), cmp: cmp}
+	for k := len(h.items)/2 - 1; k >= 0; k-- {
+		h.sink(k)
+	}
+	return h
+}
+
+// Returns the number of items in the heap
+func (h *BinaryHeap[T]) Len() int {
+	return len(h.items)
+}
+
+// Adds an item to the heap
+func (h *BinaryHeap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.swim(len(h.items) - 1)
+}
+
+// Returns the smallest item, and false if the heap is empty
+func (h *BinaryHeap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// Removes and returns the smallest item, and false if the heap is empty
+func (h *BinaryHeap[T]) Pop() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	top := h.items[0]
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	// clear the slot so a popped pointer is not kept alive
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+	h.sink(0)
+	return top, true
+}
+
+// Moves the item at k up until its parent is no larger
+func (h *BinaryHeap[T]) swim(k int) {
+	for k > 0 {
+		parent := (k - 1) / 2
+		if h.cmp(h.items[k], h.items[parent]) >= 0 {
+			return
+		}
+		h.items[k], h.items[parent] = h.items[parent], h.items[k]
+		k = parent
+	}
+}
+
+// Moves the item at k down until both its children are no smaller
+func (h *BinaryHeap[T]) sink(k int) {
+	n := len(h.items)
+	for 2*k+1 < n {
+		j := 2*k + 1
+		if j+1 < n && h.cmp(h.items[j+1], h.items[j]) < 0 {
+			j++
+		}
+		if h.cmp(h.items[k], h.items[j]) <= 0 {
+			return
+		}
+		h.items[k], h.items[j] = h.items[j], h.items[k]
+		k = j
+	}
+}
+
+// Checks that no item is smaller than its parent
+func (h *BinaryHeap[T]) check() error {
+	for k := 1; k < len(h.items); k++ {
+		if h.cmp(h.items[k], h.items[(k-1)/2]) < 0 {
+			return fmt.Errorf("item %d is smaller than its parent", k)
+		}
+	}
+	return nil
+}
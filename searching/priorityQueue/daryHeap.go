@@ -0,0 +1,99 @@
+package priorityQueue
+
+import (
+	"fmt"
+)
+
+// A d-ary heap: like BinaryHeap but every item has d children, at
+// d·i+1 ... d·i+d. A wider heap is shallower, so Push is cheaper,
+// O(log_d n), while Pop compares d children per level, O(d log_d n):
+// worth it when pushes or key decreases outnumber pops.
+// https://en.wikipedia.org/wiki/D-ary_heap
+type DaryHeap[T any] struct {
+	d     int
+	items []T
+	cmp   func(a T, b T) int
+}
+
+// Returns an empty heap with d children per item ordered by cmp; d must be at least 2
+func NewDaryHeap[T any](d int, cmp func(a T, b T) int) *DaryHeap[T] {
+	if d < 2 {
+		panic("priorityQueue: a d-ary heap needs d >= 2")
+	}
+	return &DaryHeap[T]{d: d, cmp: cmp}
+}
+
+// Returns the number of items in the heap
+func (h *DaryHeap[T]) Len() int {
+	return len(h.items)
+}
+
+// Adds an item to the heap
+func (h *DaryHeap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	k := len(h.items) - 1
+	for k > 0 {
+		parent := (k - 1) / h.d
+		if h.cmp(h.items[k], h.items[parent]) >= 0 {
+			break
+		}
+		h.items[k], h.items[parent] = h.items[parent], h.items[k]
+		k = parent
+	}
+}
+
+// Returns the smallest item, and false if the heap is empty
+func (h *DaryHeap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// Removes and returns the smallest item, and false if the heap is empty
+func (h *DaryHeap[T]) Pop() (T, bool) {
+	var zero T
+	if len(h.items) == 0 {
+		return zero, false
+	}
+	top := h.items[0]
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	h.items[last] = zero
+	h.items = h.items[:last]
+	h.sink(0)
+	return top, true
+}
+
+// Moves the item at k down until all its children are no smaller
+func (h *DaryHeap[T]) sink(k int) {
+	n := len(h.items)
+	for {
+		first := h.d*k + 1
+		if first >= n {
+			return
+		}
+		j := first
+		for c := first + 1; c < min(first+h.d, n); c++ {
+			if h.cmp(h.items[c], h.items[j]) < 0 {
+				j = c
+			}
+		}
+		if h.cmp(h.items[k], h.items[j]) <= 0 {
+			return
+		}
+		h.items[k], h.items[j] = h.items[j], h.items[k]
+		k = j
+	}
+}
+
+// Checks that no item is smaller than its parent
+func (h *DaryHeap[T]) check() error {
+	for k := 1; k < len(h.items); k++ {
+		if h.cmp(h.items[k], h.items[(k-1)/h.d]) < 0 {
+			return fmt.Errorf("item %d is smaller than its parent", k)
+		}
+	}
+	return nil
+}
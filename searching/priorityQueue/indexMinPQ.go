@@ -0,0 +1,205 @@
+package priorityQueue
+
+import (
+	"fmt"
+)
+
+// A priority queue of keys associated with the indices 0 ... maxN-1, a
+// binary heap of indices plus the inverse map from index to heap position,
+// so a queued index can have its key changed or be removed in O(log n).
+// This is what Dijkstra's and Prim's algorithms need: the index is a
+// vertex and its key the best distance so far.
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/IndexMinPQ.java.html
+type IndexMinPQ[K any] struct {
+	pq   []int // the heap of indices
+	qp   []int // qp[i] is the position of index i in pq, -1 if it is not queued
+	keys []K   // keys[i] is the key of index i
+	cmp  func(a K, b K) int
+}
+
+// Returns an empty queue for the indices 0 ... maxN-1 with keys ordered by cmp
+func NewIndexMinPQ[K any](maxN int, cmp func(a K, b K) int) *IndexMinPQ[K] {
+	if maxN < 0 {
+		panic("priorityQueue: negative capacity")
+	}
+	q := &IndexMinPQ[K]{
+		pq:   make([]int, 0, maxN),
+		qp:   make([]int, maxN),
+		keys: make([]K, maxN),
+		cmp:  cmp,
+	}
+	for i := range q.qp {
+		q.qp[i] = -1
+	}
+	return q
+}
+
+func (q *IndexMinPQ[K]) checkIndex(i int) {
+	if i < 0 || i >= len(q.qp) {
+		panic("priorityQueue: index out of range")
+	}
+}
+
+// Panics unless index i is in the queue
+func (q *IndexMinPQ[K]) checkQueued(i int) {
+	if !q.Contains(i) {
+		panic("priorityQueue: index is not in the queue")
+	}
+}
+
+// Returns the number of indices in the queue
+func (q *IndexMinPQ[K]) Len() int {
+	return len(q.pq)
+}
+
+// Returns true if index i is in the queue
+func (q *IndexMinPQ[K]) Contains(i int) bool {
+	q.checkIndex(i)
+	return q.qp[i] != -1
+}
+
+// Adds index i with the given key; i must not be in the queue already
+func (q *IndexMinPQ[K]) Insert(i int, key K) {
+	if q.Contains(i) {
+		panic("priorityQueue: index is already in the queue")
+	}
+	q.qp[i] = len(q.pq)
+	q.pq = append(q.pq, i)
+	q.keys[i] = key
+	q.swim(q.qp[i])
+}
+
+// Returns the index with the smallest key, and false if the queue is empty
+func (q *IndexMinPQ[K]) MinIndex() (int, bool) {
+	if len(q.pq) == 0 {
+		return 0, false
+	}
+	return q.pq[0], true
+}
+
+// Returns the smallest key, and false if the queue is empty
+func (q *IndexMinPQ[K]) MinKey() (K, bool) {
+	if len(q.pq) == 0 {
+		var zero K
+		return zero, false
+	}
+	return q.keys[q.pq[0]], true
+}
+
+// Removes the index with the smallest key and returns it, and false if the queue is empty
+func (q *IndexMinPQ[K]) DelMin() (int, bool) {
+	if len(q.pq) == 0 {
+		return 0, false
+	}
+	i := q.pq[0]
+	q.Delete(i)
+	return i, true
+}
+
+// Returns the key of index i, which must be in the queue
+func (q *IndexMinPQ[K]) KeyOf(i int) K {
+	q.checkQueued(i)
+	return q.keys[i]
+}
+
+// Sets the key of index i, which must be in the queue
+func (q *IndexMinPQ[K]) ChangeKey(i int, key K) {
+	q.checkQueued(i)
+	q.keys[i] = key
+	q.swim(q.qp[i])
+	q.sink(q.qp[i])
+}
+
+// Lowers the key of index i, which must be in the queue; panics if key is
+// greater than the current key
+func (q *IndexMinPQ[K]) DecreaseKey(i int, key K) {
+	q.checkQueued(i)
+	if q.cmp(key, q.keys[i]) > 0 {
+		panic("priorityQueue: DecreaseKey with a greater key")
+	}
+	q.keys[i] = key
+	q.swim(q.qp[i])
+}
+
+// Raises the key of index i, which must be in the queue; panics if key is
+// less than the current key
+func (q *IndexMinPQ[K]) IncreaseKey(i int, key K) {
+	q.checkQueued(i)
+	if q.cmp(key, q.keys[i]) < 0 {
+		panic("priorityQueue: IncreaseKey with a smaller key")
+	}
+	q.keys[i] = key
+	q.sink(q.qp[i])
+}
+
+// Removes index i and its key from the queue; i must be in the queue
+func (q *IndexMinPQ[K]) Delete(i int) {
+	q.checkQueued(i)
+	pos := q.qp[i]
+	last := len(q.pq) - 1
+	q.exchange(pos, last)
+	q.pq = q.pq[:last]
+	q.qp[i] = -1
+	var zero K
+	q.keys[i] = zero
+	if pos < last {
+		// the item moved into pos may belong above or below it
+		q.swim(pos)
+		q.sink(pos)
+	}
+}
+
+// Returns true if the key at heap position a is less than the one at b
+func (q *IndexMinPQ[K]) less(a int, b int) bool {
+	return q.cmp(q.keys[q.pq[a]], q.keys[q.pq[b]]) < 0
+}
+
+func (q *IndexMinPQ[K]) exchange(a int, b int) {
+	q.pq[a], q.pq[b] = q.pq[b], q.pq[a]
+	q.qp[q.pq[a]] = a
+	q.qp[q.pq[b]] = b
+}
+
+func (q *IndexMinPQ[K]) swim(k int) {
+	for k > 0 && q.less(k, (k-1)/2) {
+		q.exchange(k, (k-1)/2)
+		k = (k - 1) / 2
+	}
+}
+
+func (q *IndexMinPQ[K]) sink(k int) {
+	n := len(q.pq)
+	for 2*k+1 < n {
+		j := 2*k + 1
+		if j+1 < n && q.less(j+1, j) {
+			j++
+		}
+		if !q.less(j, k) {
+			return
+		}
+		q.exchange(k, j)
+		k = j
+	}
+}
+
+// Checks heap order and that pq and qp are inverses
+func (q *IndexMinPQ[K]) check() error {
+	for k, i := range q.pq {
+		if q.qp[i] != k {
+			return fmt.Errorf("index %d is at %d but recorded at %d", i, k, q.qp[i])
+		}
+		if k > 0 && q.less(k, (k-1)/2) {
+			return fmt.Errorf("index %d is smaller than its parent", i)
+		}
+	}
+	queued := 0
+	for _, pos := range q.qp {
+		if pos != -1 {
+			queued++
+		}
+	}
+	if queued != len(q.pq) {
+		return fmt.Errorf("%d indices recorded, %d in the heap", queued, len(q.pq))
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package priorityQueue
+
+import (
+	"fmt"
+)
+
+type entry struct {
+	key int
+	val int
+}
+
+// A double-ended priority queue of int keys with int values: a complete
+// binary tree in a slice whose nodes each hold two entries, an interval
+// [lo, hi], nested inside the interval of the parent. The lows form a
+// min-heap and the highs a max-heap, so both ends are at the root.
+// Push, PopMin and PopMax are O(log n). Equal keys come out in no
+// particular order. The zero value is an empty heap.
+// https://en.wikipedia.org/wiki/Double-ended_priority_queue#Interval_heaps
+type IntervalHeap struct {
+	items []entry // node k holds the low items[2k] and the high items[2k+1]
+}
+
+func NewIntervalHeap() *IntervalHeap {
+	return new(IntervalHeap)
+}
+
+// Returns the number of key-value pairs in the heap
+func (h *IntervalHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *IntervalHeap) less(a int, b int) bool {
+	return h.items[a].key < h.items[b].key
+}
+
+func (h *IntervalHeap) swap(a int, b int) {
+	h.items[a], h.items[b] = h.items[b], h.items[a]
+}
+
+// Adds a key-value pair; the key may already be in the heap
+func (h *IntervalHeap) Push(key int, val int) {
+	h.items = append(h.items, entry{key, val})
+	i := len(h.items) - 1
+	if i%2 == 1 {
+		// the new entry completes a node: order the pair, then it can only
+		// be out of place on the side it landed on
+		if h.less(i, i-1) {
+			h.swap(i, i-1)
+			h.swimMin(i - 1)
+		} else {
+			h.swimMax(i)
+		}
+		return
+	}
+	// the new entry is alone in a new node and may belong to either side
+	if i == 0 {
+		return
+	}
+	parent := (i/2 - 1) / 2
+	if h.less(i, 2*parent) {
+		h.swimMin(i)
+	} else if h.less(2*parent+1, i) {
+		h.swimMax(i)
+	}
+}
+
+// Moves the low at i up while it is below its parent's low
+func (h *IntervalHeap) swimMin(i int) {
+	for k := i / 2; k > 0; k = i / 2 {
+		parentLo := 2 * ((k - 1) / 2)
+		if !h.less(i, parentLo) {
+			return
+		}
+		h.swap(i, parentLo)
+		i = parentLo
+	}
+}
+
+// Moves the high at i up while it is above its parent's high
+func (h *IntervalHeap) swimMax(i int) {
+	for k := i / 2; k > 0; k = i / 2 {
+		parentHi := 2*((k-1)/2) + 1
+		if !h.less(parentHi, i) {
+			return
+		}
+		h.swap(i, parentHi)
+		i = parentHi
+	}
+}
+
+// Moves the low at i down while a child's low is below it
+func (h *IntervalHeap) sinkMin(i int) {
+	n := len(h.items)
+	for {
+		if i+1 < n && h.less(i+1, i) {
+			h.swap(i, i+1)
+		}
+		j := -1
+		for _, c := range []int{i + 1, i + 2} {
+			// node i/2 has children 2(i/2)+1 and 2(i/2)+2, whose lows are at 2c
+			if pos := 2 * c; pos < n && (j == -1 || h.less(pos, j)) {
+				j = pos
+			}
+		}
+		if j == -1 || !h.less(j, i) {
+			return
+		}
+		h.swap(i, j)
+		i = j
+	}
+}
+
+// Moves the high at i down while a child's high is above it
+func (h *IntervalHeap) sinkMax(i int) {
+	n := len(h.items)
+	for {
+		if h.less(i, i-1) {
+			h.swap(i, i-1)
+		}
+		j := -1
+		for _, c := range []int{i, i + 1} {
+			// node i/2 has children i and i+1 since i is odd; a child alone
+			// in its node is its own high
+			pos := 2*c + 1
+			if pos >= n {
+				pos = 2 * c
+			}
+			if pos < n && (j == -1 || h.less(j, pos)) {
+				j = pos
+			}
+		}
+		if j == -1 || !h.less(i, j) {
+			return
+		}
+		h.swap(i, j)
+		if j%2 == 0 {
+			// a lone entry in the last node
+			return
+		}
+		i = j
+	}
+}
+
+// Returns the entry at i after moving the last entry into its place
+func (h *IntervalHeap) removeAt(i int) entry {
+	res := h.items[i]
+	last := len(h.items) - 1
+	h.items[i] = h.items[last]
+	h.items = h.items[:last]
+	return res
+}
+
+// Returns the smallest key and its value, and false if the heap is empty
+func (h *IntervalHeap) PeekMin() (key int, val int, ok bool) {
+	if len(h.items) == 0 {
+		return 0, 0, false
+	}
+	return h.items[0].key, h.items[0].val, true
+}
+
+// Returns the largest key and its value, and false if the heap is empty
+func (h *IntervalHeap) PeekMax() (key int, val int, ok bool) {
+	if len(h.items) == 0 {
+		return 0, 0, false
+	}
+	top := h.items[min(1, len(h.items)-1)]
+	return top.key, top.val, true
+}
+
+// Removes and returns the smallest key and its value, and false if the heap is empty
+func (h *IntervalHeap) PopMin() (key int, val int, ok bool) {
+	if len(h.items) == 0 {
+		return 0, 0, false
+	}
+	res := h.removeAt(0)
+	if len(h.items) > 0 {
+		h.sinkMin(0)
+	}
+	return res.key, res.val, true
+}
+
+// Removes and returns the largest key and its value, and false if the heap is empty
+func (h *IntervalHeap) PopMax() (key int, val int, ok bool) {
+	if len(h.items) <= 1 {
+		return h.PopMin()
+	}
+	res := h.removeAt(1)
+	if len(h.items) > 1 {
+		h.sinkMax(1)
+	}
+	return res.key, res.val, true
+}
+
+// Checks that every node's low is at most its high and that its interval
+// lies within its parent's
+func (h *IntervalHeap) check() error {
+	n := len(h.items)
+	for i := 1; i < n; i++ {
+		k := i / 2
+		if i%2 == 1 && h.less(i, i-1) {
+			return fmt.Errorf("node %d has its high below its low", k)
+		}
+		if k == 0 {
+			continue
+		}
+		parent := (k - 1) / 2
+		if h.less(i, 2*parent) || h.less(2*parent+1, i) {
+			return fmt.Errorf("entry %d of node %d lies outside its parent's interval", i, k)
+		}
+	}
+	return nil
+}
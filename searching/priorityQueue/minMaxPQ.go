@@ -0,0 +1,87 @@
+package priorityQueue
+
+import (
+	"algo/searching/AVLTree"
+)
+
+// A double-ended priority queue of int keys with int values on top of the
+// AVL tree, which holds each distinct key once; the values queued under a
+// key wait in a bucket, so duplicate keys are allowed. Equal keys come out
+// in the order they went in, from either end. Every operation is O(log n).
+// The zero value is an empty queue.
+type MinMaxPQ struct {
+	tree    AVLTree.AVL   // the distinct keys, each with the length of its bucket
+	buckets map[int][]int // the values queued under each key, oldest first
+	n       int
+}
+
+func NewMinMaxPQ() *MinMaxPQ {
+	return new(MinMaxPQ)
+}
+
+// Returns the number of key-value pairs in the queue
+func (q *MinMaxPQ) Len() int {
+	return q.n
+}
+
+// Adds a key-value pair; the key may already be in the queue
+func (q *MinMaxPQ) Push(key int, val int) {
+	if q.buckets == nil {
+		q.buckets = make(map[int][]int)
+	}
+	q.buckets[key] = append(q.buckets[key], val)
+	q.tree.Put(key, len(q.buckets[key]))
+	q.n++
+}
+
+// Returns the smallest key and its oldest value, and false if the queue is empty
+func (q *MinMaxPQ) PeekMin() (key int, val int, ok bool) {
+	if q.n == 0 {
+		return 0, 0, false
+	}
+	key, _ = q.tree.Min()
+	return key, q.buckets[key][0], true
+}
+
+// Returns the largest key and its oldest value, and false if the queue is empty
+func (q *MinMaxPQ) PeekMax() (key int, val int, ok bool) {
+	if q.n == 0 {
+		return 0, 0, false
+	}
+	key, _ = q.tree.Max()
+	return key, q.buckets[key][0], true
+}
+
+// Removes the oldest value of key from its bucket, and key from the tree
+// once the bucket is empty
+func (q *MinMaxPQ) take(key int) int {
+	bucket := q.buckets[key]
+	val := bucket[0]
+	if len(bucket) == 1 {
+		delete(q.buckets, key)
+		q.tree.Delete(key)
+	} else {
+		q.buckets[key] = bucket[1:]
+		q.tree.Put(key, len(bucket)-1)
+	}
+	q.n--
+	return val
+}
+
+// Removes and returns the smallest key and its oldest value, and false if the queue is empty
+func (q *MinMaxPQ) PopMin() (key int, val int, ok bool) {
+	if q.n == 0 {
+		return 0, 0, false
+	}
+	key, _ = q.tree.Min()
+	return key, q.take(key), true
+}
+
+// Removes and returns the largest key and its oldest value, and false if the queue is empty
+func (q *MinMaxPQ) PopMax() (key int, val int, ok bool) {
+	if q.n == 0 {
+		return 0, 0, false
+	}
+	key, _ = q.tree.Max()
+	return key, q.take(key), true
+}
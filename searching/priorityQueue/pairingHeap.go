@@ -0,0 +1,134 @@
+package priorityQueue
+
+import (
+	"fmt"
+)
+
+type pairingNode[T any] struct {
+	item    T
+	child   *pairingNode[T] // the first child
+	sibling *pairingNode[T] // the next child of the same parent
+}
+
+// A pairing heap: a heap-ordered multiway tree kept as first-child and
+// next-sibling links. Push and Meld just link two roots, O(1); Pop removes
+// the root and pairs up its children left to right, then links the pairs
+// right to left, O(log n) amortized.
+// https://en.wikipedia.org/wiki/Pairing_heap
+type PairingHeap[T any] struct {
+	root *pairingNode[T]
+	n    int
+	cmp  func(a T, b T) int
+}
+
+// Returns an empty heap ordered by cmp
+func NewPairingHeap[T any](cmp func(a T, b T) int) *PairingHeap[T] {
+	return &PairingHeap[T]{cmp: cmp}
+}
+
+// Returns the number of items in the heap
+func (h *PairingHeap[T]) Len() int {
+	return h.n
+}
+
+// Makes the root with the larger item the first child of the other
+func (h *PairingHeap[T]) link(a *pairingNode[T], b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp(b.item, a.item) < 0 {
+		a, b = b, a
+	}
+	b.sibling = a.child
+	a.child = b
+	return a
+}
+
+// Adds an item to the heap
+func (h *PairingHeap[T]) Push(item T) {
+	h.root = h.link(h.root, &pairingNode[T]{item: item})
+	h.n++
+}
+
+// Returns the smallest item, and false if the heap is empty
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.item, true
+}
+
+// Removes and returns the smallest item, and false if the heap is empty
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	top := h.root.item
+	// first pass: link the children in pairs, left to right
+	var pairs []*pairingNode[T]
+	for c := h.root.child; c != nil; {
+		a, b := c, c.sibling
+		if b == nil {
+			c = nil
+		} else {
+			c = b.sibling
+			b.sibling = nil
+		}
+		a.sibling = nil
+		pairs = append(pairs, h.link(a, b))
+	}
+	// second pass: link the pairs right to left
+	var root *pairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	h.root = root
+	h.n--
+	return top, true
+}
+
+// Moves every item of other into h in O(1), leaving other empty.
+// Both heaps must order their items the same way.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if h == other {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.n += other.n
+	other.root, other.n = nil, 0
+}
+
+// Checks heap order and the item count
+func (h *PairingHeap[T]) check() error {
+	if h.root != nil && h.root.sibling != nil {
+		return fmt.Errorf("the root has a sibling")
+	}
+	count := 0
+	var visit func(node *pairingNode[T]) error
+	visit = func(node *pairingNode[T]) error {
+		count++
+		for c := node.child; c != nil; c = c.sibling {
+			if h.cmp(c.item, node.item) < 0 {
+				return fmt.Errorf("a child is smaller than its parent")
+			}
+			if err := visit(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if h.root != nil {
+		if err := visit(h.root); err != nil {
+			return err
+		}
+	}
+	if count != h.n {
+		return fmt.Errorf("heap holds %d items, size is %d", count, h.n)
+	}
+	return nil
+}
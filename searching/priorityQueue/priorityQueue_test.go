@@ -0,0 +1,375 @@
+package priorityQueue
+
+import (
+	"algo/searching/AVLTree"
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// The API shared by the heaps
+type heap interface {
+	Len() int
+	Push(item int)
+	Peek() (int, bool)
+	Pop() (int, bool)
+	check() error
+}
+
+var heaps = []struct {
+	name string
+	new  func(cmp func(a int, b int) int) heap
+}{
+	{"BinaryHeap", func(cmp func(a int, b int) int) heap { return NewBinaryHeap(cmp) }},
+	{"DaryHeap2", func(cmp func(a int, b int) int) heap { return NewDaryHeap(2, cmp) }},
+	{"DaryHeap3", func(cmp func(a int, b int) int) heap { return NewDaryHeap(3, cmp) }},
+	{"DaryHeap4", func(cmp func(a int, b int) int) heap { return NewDaryHeap(4, cmp) }},
+	{"PairingHeap", func(cmp func(a int, b int) int) heap { return NewPairingHeap(cmp) }},
+}
+
+func Test1(t *testing.T) {
+	for _, backend := range heaps {
+		h := backend.new(cmp.Compare[int])
+		if _, ok := h.Pop(); ok || h.Len() != 0 {
+			t.Error(backend.name + " Empty Wrong")
+		}
+		for _, v := range []int{5, 3, 8, 3, 1} {
+			h.Push(v)
+		}
+		if top, ok := h.Peek(); !ok || top != 1 || h.Len() != 5 {
+			t.Error(backend.name + " Peek Wrong")
+		}
+		var popped []int
+		for h.Len() > 0 {
+			v, _ := h.Pop()
+			popped = append(popped, v)
+		}
+		if !slices.Equal(popped, []int{1, 3, 3, 5, 8}) {
+			t.Error(backend.name+" Pop Wrong", popped)
+		}
+		// a reversed comparison gives a max heap
+		h = backend.new(func(a int, b int) int { return cmp.Compare(b, a) })
+		h.Push(1)
+		h.Push(9)
+		h.Push(4)
+		if top, _ := h.Pop(); top != 9 {
+			t.Error(backend.name + " Max Heap Wrong")
+		}
+	}
+}
+
+// random pushes and pops against a sorted slice
+func Test2(t *testing.T) {
+	for _, backend := range heaps {
+		r := rand.New(rand.NewPCG(1, 1))
+		h := backend.new(cmp.Compare[int])
+		var ref []int
+		for op := 0; op < 3000; op++ {
+			if r.IntN(3) > 0 {
+				v := r.IntN(100)
+				h.Push(v)
+				ref = append(ref, v)
+				slices.Sort(ref)
+			} else {
+				v, ok := h.Pop()
+				if ok != (len(ref) > 0) || (ok && v != ref[0]) {
+					t.Fatalf("%s op %d: Pop() = %d %v, want %v", backend.name, op, v, ok, ref)
+				}
+				if ok {
+					ref = ref[1:]
+				}
+			}
+			if h.Len() != len(ref) {
+				t.Fatalf("%s op %d: Len() = %d, want %d", backend.name, op, h.Len(), len(ref))
+			}
+			if err := h.check(); err != nil {
+				t.Fatalf("%s op %d: %v", backend.name, op, err)
+			}
+		}
+	}
+}
+
+func Test3(t *testing.T) {
+	h := BinaryHeapFrom([]int{9, 4, 7, 1, 8, 2}, cmp.Compare[int])
+	if h.check() != nil || h.Len() != 6 {
+		t.Error("BinaryHeapFrom Wrong")
+	}
+	a, b := NewPairingHeap(cmp.Compare[int]), NewPairingHeap(cmp.Compare[int])
+	for i := 0; i < 10; i++ {
+		a.Push(2 * i)
+		b.Push(2*i + 1)
+	}
+	a.Meld(b)
+	if a.Len() != 20 || b.Len() != 0 || a.check() != nil || b.check() != nil {
+		t.Error("Meld Wrong")
+	}
+	for want := 0; want < 20; want++ {
+		if v, _ := a.Pop(); v != want {
+			t.Fatal("Meld Order Wrong")
+		}
+	}
+}
+
+func Test4(t *testing.T) {
+	q := NewIndexMinPQ(10, cmp.Compare[string])
+	for i, key := range []string{"it", "was", "the", "best", "of", "times"} {
+		q.Insert(i, key)
+	}
+	if i, _ := q.MinIndex(); i != 3 || q.KeyOf(5) != "times" || !q.Contains(5) || q.Contains(6) {
+		t.Error("Insert Wrong")
+	}
+	q.DecreaseKey(5, "a")
+	q.ChangeKey(3, "zzz")
+	q.Delete(0)
+	var order []int
+	for q.Len() > 0 {
+		i, _ := q.DelMin()
+		order = append(order, i)
+	}
+	// a, of, the, was, zzz
+	if !slices.Equal(order, []int{5, 4, 2, 1, 3}) {
+		t.Error("DelMin Order Wrong", order)
+	}
+	for _, bad := range []func(){
+		func() { q.Insert(10, "x") },
+		func() { q.KeyOf(1) },
+		func() { q.Insert(2, "b"); q.DecreaseKey(2, "c") },
+		func() { q.Insert(2, "b") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Misuse Accepted")
+				}
+			}()
+			bad()
+		}()
+	}
+}
+
+// random operations on an IndexMinPQ against a map
+func Test5(t *testing.T) {
+	r := rand.New(rand.NewPCG(2, 2))
+	const maxN = 30
+	q := NewIndexMinPQ(maxN, cmp.Compare[int])
+	ref := make(map[int]int)
+	for op := 0; op < 5000; op++ {
+		i, key := r.IntN(maxN), r.IntN(50)
+		switch r.IntN(5) {
+		case 0:
+			if _, ok := ref[i]; !ok {
+				q.Insert(i, key)
+				ref[i] = key
+			}
+		case 1:
+			if _, ok := ref[i]; ok {
+				q.ChangeKey(i, key)
+				ref[i] = key
+			}
+		case 2:
+			if old, ok := ref[i]; ok && key <= old {
+				q.DecreaseKey(i, key)
+				ref[i] = key
+			}
+		case 3:
+			if _, ok := ref[i]; ok {
+				q.Delete(i)
+				delete(ref, i)
+			}
+		case 4:
+			got, ok := q.DelMin()
+			if ok != (len(ref) > 0) {
+				t.Fatalf("op %d: DelMin() ok = %v with %d queued", op, ok, len(ref))
+			}
+			if ok {
+				for _, key := range ref {
+					if key < ref[got] {
+						t.Fatalf("op %d: DelMin() = %d with key %d, %d is smaller", op, got, ref[got], key)
+					}
+				}
+				delete(ref, got)
+			}
+		}
+		if q.Len() != len(ref) {
+			t.Fatalf("op %d: Len() = %d, want %d", op, q.Len(), len(ref))
+		}
+		if err := q.check(); err != nil {
+			t.Fatalf("op %d: %v", op, err)
+		}
+	}
+}
+
+// Filling a queue with n distinct random keys and popping them all,
+// against the same through AVL.Put and AVL.DeleteMin
+func BenchmarkPop(b *testing.B) {
+	for _, n := range []int{1e3, 1e5} {
+		keys := rand.New(rand.NewPCG(1, 2)).Perm(n)
+		for _, backend := range heaps {
+			b.Run(backend.name+"/n="+strconv.Itoa(n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					h := backend.new(cmp.Compare[int])
+					for _, key := range keys {
+						h.Push(key)
+					}
+					for h.Len() > 0 {
+						h.Pop()
+					}
+				}
+			})
+		}
+		b.Run("IndexMinPQ/n="+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				q := NewIndexMinPQ(n, cmp.Compare[int])
+				for j, key := range keys {
+					q.Insert(j, key)
+				}
+				for q.Len() > 0 {
+					q.DelMin()
+				}
+			}
+		})
+		b.Run("AVL/n="+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tree := new(AVLTree.AVL)
+				for _, key := range keys {
+					tree.Put(key, key)
+				}
+				for tree.Size() > 0 {
+					tree.Min()
+					tree.DeleteMin()
+				}
+			}
+		})
+	}
+}
+
+// The API shared by the double-ended queues
+type minMaxPQ interface {
+	Len() int
+	Push(key int, val int)
+	PeekMin() (int, int, bool)
+	PeekMax() (int, int, bool)
+	PopMin() (int, int, bool)
+	PopMax() (int, int, bool)
+}
+
+var minMaxPQs = []struct {
+	name string
+	new  func() minMaxPQ
+}{
+	{"MinMaxPQ", func() minMaxPQ { return NewMinMaxPQ() }},
+	{"IntervalHeap", func() minMaxPQ { return NewIntervalHeap() }},
+}
+
+// duplicate keys, both ends, and the empty queue
+func Test6(t *testing.T) {
+	for _, backend := range minMaxPQs {
+		q := backend.new()
+		if _, _, ok := q.PopMax(); ok || q.Len() != 0 {
+			t.Error(backend.name + " Empty Wrong")
+		}
+		for i, k := range []int{5, 3, 8, 3, 8, 1} {
+			q.Push(k, i)
+		}
+		if k, _, ok := q.PeekMin(); !ok || k != 1 || q.Len() != 6 {
+			t.Error(backend.name + " PeekMin Wrong")
+		}
+		if k, _, ok := q.PeekMax(); !ok || k != 8 {
+			t.Error(backend.name + " PeekMax Wrong")
+		}
+		var keys []int
+		for q.Len() > 0 {
+			lo, _, _ := q.PopMin()
+			keys = append(keys, lo)
+			if hi, _, ok := q.PopMax(); ok {
+				keys = append(keys, hi)
+			}
+		}
+		if !slices.Equal(keys, []int{1, 8, 3, 8, 3, 5}) {
+			t.Error(backend.name+" Pop Wrong", keys)
+		}
+	}
+	// MinMaxPQ hands out equal keys first in first out
+	q := NewMinMaxPQ()
+	q.Push(2, 10)
+	q.Push(2, 20)
+	q.Push(2, 30)
+	if _, v, _ := q.PopMax(); v != 10 {
+		t.Error("MinMaxPQ FIFO Wrong")
+	}
+	if _, v, _ := q.PopMin(); v != 20 {
+		t.Error("MinMaxPQ FIFO Wrong")
+	}
+}
+
+// random pushes and pops from both ends against a sorted slice
+func Test7(t *testing.T) {
+	for _, backend := range minMaxPQs {
+		r := rand.New(rand.NewPCG(7, 7))
+		q := backend.new()
+		var ref []int
+		for i := 0; i < 5000; i++ {
+			switch r.IntN(3) {
+			case 0:
+				k := r.IntN(50)
+				q.Push(k, k*10)
+				ref = append(ref, k)
+				slices.Sort(ref)
+			case 1:
+				k, v, ok := q.PopMin()
+				if ok != (len(ref) > 0) || ok && (k != ref[0] || v != k*10) {
+					t.Fatal(backend.name+" PopMin Wrong", i)
+				}
+				if ok {
+					ref = ref[1:]
+				}
+			case 2:
+				k, v, ok := q.PopMax()
+				if ok != (len(ref) > 0) || ok && (k != ref[len(ref)-1] || v != k*10) {
+					t.Fatal(backend.name+" PopMax Wrong", i)
+				}
+				if ok {
+					ref = ref[:len(ref)-1]
+				}
+			}
+			if q.Len() != len(ref) {
+				t.Fatal(backend.name+" Len Wrong", i)
+			}
+			if h, ok := q.(*IntervalHeap); ok {
+				if err := h.check(); err != nil {
+					t.Fatal(backend.name, i, err)
+				}
+			}
+		}
+	}
+}
+
+// a bounded top-k buffer keeps the k largest keys seen
+func Test8(t *testing.T) {
+	for _, backend := range minMaxPQs {
+		r := rand.New(rand.NewPCG(8, 8))
+		q := backend.new()
+		const k = 10
+		var all []int
+		for i := 0; i < 1000; i++ {
+			key := r.IntN(500)
+			all = append(all, key)
+			q.Push(key, i)
+			if q.Len() > k {
+				q.PopMin()
+			}
+		}
+		slices.Sort(all)
+		var top []int
+		for q.Len() > 0 {
+			key, _, _ := q.PopMax()
+			top = append(top, key)
+		}
+		slices.Reverse(top)
+		if !slices.Equal(top, all[len(all)-k:]) {
+			t.Error(backend.name+" Top-k Wrong", top)
+		}
+	}
+}
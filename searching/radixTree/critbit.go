@@ -0,0 +1,466 @@
+// Symbol tables implemented with crit-bit trees, a binary Patricia trie:
+// IntTree for int keys and Tree for byte-string keys.
+// Every internal node branches on the first bit where the keys below it
+// differ, the critical bit, and keys sit in the leaves. The tree has exactly
+// n-1 internal nodes, its depth is bounded by the key length in bits and a
+// search looks at one bit per level and compares the whole key only once,
+// at the leaf. Internal nodes count their leaves for Rank and Select.
+// Keys are ordered by their bits, so unlike the comparison-based trees
+// these take no comparator.
+// https://cr.yp.to/critbit.html
+// https://github.com/agl/critbit/blob/master/critbit.pdf
+package radixTree
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// The bit-level view of a key the tree branches on. Bits are numbered from
+// the most significant one and keys compare like their bit strings.
+type key[K any] interface {
+	comparable
+	// Returns bit i of the key, 0 or 1
+	bit(i int) int
+	// Returns the index of the first bit where the keys differ, -1 if they are equal
+	crit(other K) int
+}
+
+// An int flipped to sort like its bit string: the sign bit is inverted so
+// that negative numbers come first
+type intKey uint64
+
+func encodeInt(k int) intKey {
+	return intKey(uint64(k) ^ 1<<63)
+}
+
+func (k intKey) decode() int {
+	return int(uint64(k) ^ 1<<63)
+}
+
+func (k intKey) bit(i int) int {
+	return int(k >> (63 - i) & 1)
+}
+
+func (k intKey) crit(other intKey) int {
+	if k == other {
+		return -1
+	}
+	return bits.LeadingZeros64(uint64(k ^ other))
+}
+
+// A byte string read as 9 bits per byte: a 1 marking that the byte is there,
+// then its 8 bits. Past the end every bit is 0, so a string sorts before
+// every longer string it is a prefix of.
+type stringKey string
+
+func (k stringKey) bit(i int) int {
+	pos := i / 9
+	if pos >= len(k) {
+		return 0
+	}
+	off := i % 9
+	if off == 0 {
+		return 1
+	}
+	return int(k[pos] >> (8 - off) & 1)
+}
+
+func (k stringKey) crit(other stringKey) int {
+	n := min(len(k), len(other))
+	for pos := 0; pos < n; pos++ {
+		if x := k[pos] ^ other[pos]; x != 0 {
+			return 9*pos + 1 + bits.LeadingZeros8(x)
+		}
+	}
+	if len(k) == len(other) {
+		return -1
+	}
+	return 9 * n
+}
+
+// A key-value pair, as returned by Floor, Ceiling and Select
+type Entry[K any] struct {
+	key K
+	val int
+}
+
+// Returns the key of the entry
+func (e *Entry[K]) Key() K {
+	return e.key
+}
+
+// Returns the value of the entry
+func (e *Entry[K]) Val() int {
+	return e.val
+}
+
+// A leaf holds a key-value pair and has no children. An internal node
+// branches on bit: keys with a 0 there go to child[0], with a 1 to child[1].
+type node[K key[K]] struct {
+	bit   int
+	child [2]*node[K]
+	size  int // number of leaves in the subtree
+	key   K
+	val   int
+}
+
+func (n *node[K]) leaf() bool {
+	return n.child[0] == nil
+}
+
+// The crit-bit tree behind IntTree and Tree
+type tree[K key[K]] struct {
+	root *node[K]
+}
+
+// have such a helper function to avoid visiting nil node
+func (t *tree[K]) size(node *node[K]) int {
+	if node == nil {
+		return 0
+	} else {
+		return node.size
+	}
+}
+
+// Returns the leaf the key leads to: the leaf holding the key if there is
+// one, otherwise a leaf sharing the longest prefix with it. nil for an empty tree.
+func (t *tree[K]) best(key K) *node[K] {
+	n := t.root
+	for n != nil && !n.leaf() {
+		n = n.child[key.bit(n.bit)]
+	}
+	return n
+}
+
+// Returns the leaf holding the key, nil if there is none
+func (t *tree[K]) get(key K) *node[K] {
+	n := t.best(key)
+	if n == nil || n.key != key {
+		return nil
+	}
+	return n
+}
+
+// Hangs a new leaf next to the first subtree on the path whose nodes branch
+// after crit, the bit where the key leaves the path
+func (t *tree[K]) insert(n *node[K], key K, val int, crit int) *node[K] {
+	if n.leaf() || n.bit > crit {
+		parent := &node[K]{bit: crit, size: n.size + 1}
+		dir := key.bit(crit)
+		parent.child[dir] = &node[K]{key: key, val: val, size: 1}
+		parent.child[1-dir] = n
+		return parent
+	}
+	dir := key.bit(n.bit)
+	n.child[dir] = t.insert(n.child[dir], key, val, crit)
+	n.size++
+	return n
+}
+
+func (t *tree[K]) put(key K, val int) {
+	if t.root == nil {
+		t.root = &node[K]{key: key, val: val, size: 1}
+		return
+	}
+	leaf := t.best(key)
+	crit := key.crit(leaf.key)
+	if crit == -1 {
+		leaf.val = val
+		return
+	}
+	t.root = t.insert(t.root, key, val, crit)
+}
+
+// Removes the leaf of a key that is in the subtree; its sibling takes the
+// place of their parent
+func (t *tree[K]) delete(n *node[K], key K) *node[K] {
+	if n.leaf() {
+		return nil
+	}
+	dir := key.bit(n.bit)
+	child := t.delete(n.child[dir], key)
+	if child == nil {
+		return n.child[1-dir]
+	}
+	n.child[dir] = child
+	n.size--
+	return n
+}
+
+func (t *tree[K]) remove(key K) {
+	if t.get(key) != nil {
+		t.root = t.delete(t.root, key)
+	}
+}
+
+func (t *tree[K]) findMin(n *node[K]) *node[K] {
+	for n != nil && !n.leaf() {
+		n = n.child[0]
+	}
+	return n
+}
+
+func (t *tree[K]) findMax(n *node[K]) *node[K] {
+	for n != nil && !n.leaf() {
+		n = n.child[1]
+	}
+	return n
+}
+
+// Return the number of keys in the tree strictly less than `key`
+func (t *tree[K]) rank(key K) int {
+	if t.root == nil {
+		return 0
+	}
+	// below the crit bit the keys of the subtree all sit on the same side of key
+	crit := key.crit(t.best(key).key)
+	res := 0
+	n := t.root
+	for !n.leaf() && (crit == -1 || n.bit < crit) {
+		if key.bit(n.bit) == 1 {
+			res += t.size(n.child[0])
+		}
+		n = n.child[key.bit(n.bit)]
+	}
+	if crit != -1 && key.bit(crit) == 1 {
+		res += n.size
+	}
+	return res
+}
+
+// Return the leaf whose rank is k, nil if there is none
+func (t *tree[K]) selectHelper(k int) *node[K] {
+	if k < 0 || k >= t.size(t.root) {
+		return nil
+	}
+	n := t.root
+	for !n.leaf() {
+		if k < n.child[0].size {
+			n = n.child[0]
+		} else {
+			k -= n.child[0].size
+			n = n.child[1]
+		}
+	}
+	return n
+}
+
+// Follows the key down to the subtree holding every key that agrees with
+// it before crit, the bit where it leaves the tree. Also returns the
+// nearest subtrees to the left and right of that path.
+func (t *tree[K]) descend(key K, crit int) (n *node[K], left *node[K], right *node[K]) {
+	n = t.root
+	for !n.leaf() && n.bit < crit {
+		dir := key.bit(n.bit)
+		if dir == 1 {
+			left = n.child[0]
+		} else {
+			right = n.child[1]
+		}
+		n = n.child[dir]
+	}
+	return n, left, right
+}
+
+// Returns the leaf with the largest key less than or equal to key, nil if there is none
+func (t *tree[K]) floor(key K) *node[K] {
+	if t.root == nil {
+		return nil
+	}
+	best := t.best(key)
+	crit := key.crit(best.key)
+	if crit == -1 {
+		return best
+	}
+	n, left, _ := t.descend(key, crit)
+	if key.bit(crit) == 1 {
+		// key is above the whole subtree
+		return t.findMax(n)
+	}
+	return t.findMax(left)
+}
+
+// Returns the leaf with the smallest key greater than or equal to key, nil if there is none
+func (t *tree[K]) ceiling(key K) *node[K] {
+	if t.root == nil {
+		return nil
+	}
+	best := t.best(key)
+	crit := key.crit(best.key)
+	if crit == -1 {
+		return best
+	}
+	n, _, right := t.descend(key, crit)
+	if key.bit(crit) == 0 {
+		// key is below the whole subtree
+		return t.findMin(n)
+	}
+	return t.findMin(right)
+}
+
+// Calls visit on the leaves of the subtree whose ranks there are in [lo, hi)
+func (t *tree[K]) leaves(n *node[K], lo int, hi int, visit func(*node[K])) {
+	if n == nil || hi <= 0 || lo >= n.size {
+		return
+	}
+	if n.leaf() {
+		visit(n)
+		return
+	}
+	left := n.child[0].size
+	t.leaves(n.child[0], lo, hi, visit)
+	t.leaves(n.child[1], lo-left, hi-left, visit)
+}
+
+// Returns the number of keys in [lo, hi]
+func (t *tree[K]) rangeSize(lo K, hi K) int {
+	res := t.rank(hi) - t.rank(lo)
+	if t.get(hi) != nil {
+		res++
+	}
+	return max(res, 0)
+}
+
+// Calls visit on the leaves with keys in [lo, hi], in order
+func (t *tree[K]) rangeLeaves(lo K, hi K, visit func(*node[K])) {
+	start := t.rank(lo)
+	t.leaves(t.root, start, start+t.rangeSize(lo, hi), visit)
+}
+
+// Returns true if every key of a subtree on the path of key lies on one
+// side of it. crit is where key leaves the tree, as in rank.
+func (t *tree[K]) settled(n *node[K], crit int) bool {
+	return n.leaf() || (crit != -1 && n.bit >= crit)
+}
+
+// Removes the keys in [lo, hi] from the subtree and returns what is left
+// and how many keys went. checkLo is set while the subtree may hold keys
+// less than lo and checkHi while it may hold keys greater than hi; a
+// subtree with neither is dropped whole. Only the paths of lo and hi are
+// walked, since every other subtree is settled against both at once.
+func (t *tree[K]) deleteRange(n *node[K], lo K, hi K, critLo int, critHi int, checkLo bool, checkHi bool) (*node[K], int) {
+	if checkLo && t.settled(n, critLo) {
+		if critLo != -1 && lo.bit(critLo) == 1 {
+			return n, 0 // below lo
+		}
+		checkLo = false
+	}
+	if checkHi && t.settled(n, critHi) {
+		if critHi != -1 && hi.bit(critHi) == 0 {
+			return n, 0 // above hi
+		}
+		checkHi = false
+	}
+	if !checkLo && !checkHi {
+		return nil, n.size
+	}
+	removed := 0
+	for dir, child := range n.child {
+		if (checkLo && dir < lo.bit(n.bit)) || (checkHi && dir > hi.bit(n.bit)) {
+			continue
+		}
+		var count int
+		n.child[dir], count = t.deleteRange(child, lo, hi, critLo, critHi, checkLo && dir == lo.bit(n.bit), checkHi && dir == hi.bit(n.bit))
+		removed += count
+	}
+	if n.child[0] == nil {
+		return n.child[1], removed
+	}
+	if n.child[1] == nil {
+		return n.child[0], removed
+	}
+	n.size -= removed
+	return n, removed
+}
+
+// Removes the keys in [lo, hi] and returns how many there were
+func (t *tree[K]) removeRange(lo K, hi K) int {
+	if t.root == nil {
+		return 0
+	}
+	critLo, critHi := lo.crit(t.best(lo).key), hi.crit(t.best(hi).key)
+	var removed int
+	t.root, removed = t.deleteRange(t.root, lo, hi, critLo, critHi, true, true)
+	return removed
+}
+
+// Returns a deep copy of the subtree
+func (t *tree[K]) clone(n *node[K]) *node[K] {
+	if n == nil {
+		return nil
+	}
+	copied := *n
+	for dir, child := range n.child {
+		copied.child[dir] = t.clone(child)
+	}
+	return &copied
+}
+
+// Reports whether the subtrees branch on the same bits and hold the same
+// pairs. The shape of a crit-bit tree follows from its keys, so this is
+// both content and shape equality.
+func (t *tree[K]) equal(a *node[K], b *node[K]) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.leaf() || b.leaf() {
+		return a.leaf() && b.leaf() && a.key == b.key && a.val == b.val
+	}
+	return a.bit == b.bit && a.size == b.size && t.equal(a.child[0], b.child[0]) && t.equal(a.child[1], b.child[1])
+}
+
+// Checks the invariants of the tree: leaf counts, critical bits increasing
+// down every path and each key on the side of every critical bit above it
+// that its bit there says
+func (t *tree[K]) check() error {
+	_, err := t.checkNode(t.root, -1)
+	return err
+}
+
+// Returns the leftmost leaf of the subtree. above is the critical bit of the parent.
+func (t *tree[K]) checkNode(n *node[K], above int) (*node[K], error) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.leaf() {
+		if n.child[1] != nil || n.size != 1 {
+			return nil, fmt.Errorf("leaf %v is malformed", n.key)
+		}
+		return n, nil
+	}
+	if n.child[1] == nil {
+		return nil, fmt.Errorf("node on bit %d has one child", n.bit)
+	}
+	if n.bit <= above {
+		return nil, fmt.Errorf("node on bit %d below a node on bit %d", n.bit, above)
+	}
+	if n.size != n.child[0].size+n.child[1].size {
+		return nil, fmt.Errorf("node on bit %d has size %d", n.bit, n.size)
+	}
+	left, err := t.checkNode(n.child[0], n.bit)
+	if err != nil {
+		return nil, err
+	}
+	right, err := t.checkNode(n.child[1], n.bit)
+	if err != nil {
+		return nil, err
+	}
+	// the two sides first differ at the node's bit, and every leaf below
+	// a side agrees with it on that bit
+	if left.key.crit(right.key) != n.bit || left.key.bit(n.bit) != 0 || right.key.bit(n.bit) != 1 {
+		return nil, fmt.Errorf("node on bit %d splits %v and %v", n.bit, left.key, right.key)
+	}
+	var bad *node[K]
+	for dir, child := range n.child {
+		t.leaves(child, 0, child.size, func(leaf *node[K]) {
+			if leaf.key.bit(n.bit) != dir {
+				bad = leaf
+			}
+		})
+	}
+	if bad != nil {
+		return nil, fmt.Errorf("leaf %v is on the wrong side of bit %d", bad.key, n.bit)
+	}
+	return left, nil
+}
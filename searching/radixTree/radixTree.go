@@ -0,0 +1,351 @@
+package radixTree
+
+// The struct represents an ordered symbol table of int key-value pairs.
+// The zero value is an empty tree.
+type IntTree struct {
+	t tree[intKey]
+}
+
+func NewIntTree() *IntTree {
+	return new(IntTree)
+}
+
+// Returns the entry of an IntTree leaf, nil for nil
+func intEntry(n *node[intKey]) *Entry[int] {
+	if n == nil {
+		return nil
+	}
+	return &Entry[int]{n.key.decode(), n.val}
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *IntTree) Size() int {
+	return t.t.size(t.t.root)
+}
+
+// Get value by key, return 0 if not exist
+func (t *IntTree) Get(key int) int {
+	if n := t.t.get(encodeInt(key)); n != nil {
+		return n.val
+	}
+	return 0
+}
+
+// Return true if the key exists in the symbol table
+func (t *IntTree) Contains(key int) bool {
+	return t.t.get(encodeInt(key)) != nil
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *IntTree) Put(key int, val int) {
+	t.t.put(encodeInt(key), val)
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *IntTree) Delete(key int) {
+	t.t.remove(encodeInt(key))
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (t *IntTree) DeleteMin() {
+	if n := t.t.findMin(t.t.root); n != nil {
+		t.t.remove(n.key)
+	}
+}
+
+// Removes the largest key and associated value from the symbol table
+func (t *IntTree) DeleteMax() {
+	if n := t.t.findMax(t.t.root); n != nil {
+		t.t.remove(n.key)
+	}
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *IntTree) Remove(key int) (val int, ok bool) {
+	n := t.t.get(encodeInt(key))
+	if n == nil {
+		return 0, false
+	}
+	t.t.remove(n.key)
+	return n.val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the tree is empty
+func (t *IntTree) PopMin() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Min()
+	t.DeleteMin()
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the tree is empty
+func (t *IntTree) PopMax() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Max()
+	t.DeleteMax()
+	return key, val, true
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// Only the paths of lo and hi are walked and the subtrees between them are
+// cut off whole, so the time is bounded by the key length however many keys go.
+func (t *IntTree) DeleteRange(lo int, hi int) int {
+	return t.t.removeRange(encodeInt(lo), encodeInt(hi))
+}
+
+func (t *IntTree) Min() (key int, val int) {
+	n := t.t.findMin(t.t.root)
+	return n.key.decode(), n.val
+}
+
+func (t *IntTree) Max() (key int, val int) {
+	n := t.t.findMax(t.t.root)
+	return n.key.decode(), n.val
+}
+
+// Returns the entry with the largest key in the symbol table less than or equal to key.
+func (t *IntTree) Floor(key int) *Entry[int] {
+	return intEntry(t.t.floor(encodeInt(key)))
+}
+
+// Returns the entry with the smallest key in the symbol table greater than or equal to key.
+func (t *IntTree) Ceiling(key int) *Entry[int] {
+	return intEntry(t.t.ceiling(encodeInt(key)))
+}
+
+// Return the entry in the symbol table whose rank is k
+func (t *IntTree) Select(k int) *Entry[int] {
+	return intEntry(t.t.selectHelper(k))
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (t *IntTree) Rank(key int) int {
+	return t.t.rank(encodeInt(key))
+}
+
+// Returns all keys in the symbol table in ascending order
+func (t *IntTree) Keys() []int {
+	var res []int
+	t.t.leaves(t.t.root, 0, t.Size(), func(n *node[intKey]) {
+		res = append(res, n.key.decode())
+	})
+	return res
+}
+
+// Returns all keys in the symbol table in the given range.
+func (t *IntTree) RangeKeys(lo int, hi int) []int {
+	var res []int
+	t.t.rangeLeaves(encodeInt(lo), encodeInt(hi), func(n *node[intKey]) {
+		res = append(res, n.key.decode())
+	})
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (t *IntTree) RangeSize(lo int, hi int) int {
+	return t.t.rangeSize(encodeInt(lo), encodeInt(hi))
+}
+
+// Returns a deep copy of the tree. O(n).
+func (t *IntTree) Clone() *IntTree {
+	return &IntTree{tree[intKey]{t.t.clone(t.t.root)}}
+}
+
+// Reports whether the two trees hold the same key-value pairs. A crit-bit
+// tree has one shape for a set of keys, so both are compared node for node, O(n).
+func (t *IntTree) Equal(other *IntTree) bool {
+	return t.t.equal(t.t.root, other.t.root)
+}
+
+// Same as Equal: the keys decide the shape
+func (t *IntTree) EqualShape(other *IntTree) bool {
+	return t.Equal(other)
+}
+
+// Removes every key
+func (t *IntTree) Clear() {
+	t.t.root = nil
+}
+
+func (t *IntTree) check() error {
+	return t.t.check()
+}
+
+// The struct represents an ordered symbol table of byte-string keys and int
+// values, with keys in byte order. The zero value is an empty tree.
+type Tree struct {
+	t tree[stringKey]
+}
+
+func NewTree() *Tree {
+	return new(Tree)
+}
+
+// Returns the entry of a Tree leaf, nil for nil
+func stringEntry(n *node[stringKey]) *Entry[string] {
+	if n == nil {
+		return nil
+	}
+	return &Entry[string]{string(n.key), n.val}
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *Tree) Size() int {
+	return t.t.size(t.t.root)
+}
+
+// Get value by key, return 0 if not exist
+func (t *Tree) Get(key string) int {
+	if n := t.t.get(stringKey(key)); n != nil {
+		return n.val
+	}
+	return 0
+}
+
+// Return true if the key exists in the symbol table
+func (t *Tree) Contains(key string) bool {
+	return t.t.get(stringKey(key)) != nil
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *Tree) Put(key string, val int) {
+	t.t.put(stringKey(key), val)
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *Tree) Delete(key string) {
+	t.t.remove(stringKey(key))
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (t *Tree) DeleteMin() {
+	if n := t.t.findMin(t.t.root); n != nil {
+		t.t.remove(n.key)
+	}
+}
+
+// Removes the largest key and associated value from the symbol table
+func (t *Tree) DeleteMax() {
+	if n := t.t.findMax(t.t.root); n != nil {
+		t.t.remove(n.key)
+	}
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *Tree) Remove(key string) (val int, ok bool) {
+	n := t.t.get(stringKey(key))
+	if n == nil {
+		return 0, false
+	}
+	t.t.remove(n.key)
+	return n.val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the tree is empty
+func (t *Tree) PopMin() (key string, val int, ok bool) {
+	if t.Size() == 0 {
+		return "", 0, false
+	}
+	key, val = t.Min()
+	t.DeleteMin()
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the tree is empty
+func (t *Tree) PopMax() (key string, val int, ok bool) {
+	if t.Size() == 0 {
+		return "", 0, false
+	}
+	key, val = t.Max()
+	t.DeleteMax()
+	return key, val, true
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// Only the paths of lo and hi are walked and the subtrees between them are
+// cut off whole, so the time is bounded by the key length however many keys go.
+func (t *Tree) DeleteRange(lo string, hi string) int {
+	return t.t.removeRange(stringKey(lo), stringKey(hi))
+}
+
+func (t *Tree) Min() (key string, val int) {
+	n := t.t.findMin(t.t.root)
+	return string(n.key), n.val
+}
+
+func (t *Tree) Max() (key string, val int) {
+	n := t.t.findMax(t.t.root)
+	return string(n.key), n.val
+}
+
+// Returns the entry with the largest key in the symbol table less than or equal to key.
+func (t *Tree) Floor(key string) *Entry[string] {
+	return stringEntry(t.t.floor(stringKey(key)))
+}
+
+// Returns the entry with the smallest key in the symbol table greater than or equal to key.
+func (t *Tree) Ceiling(key string) *Entry[string] {
+	return stringEntry(t.t.ceiling(stringKey(key)))
+}
+
+// Return the entry in the symbol table whose rank is k
+func (t *Tree) Select(k int) *Entry[string] {
+	return stringEntry(t.t.selectHelper(k))
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (t *Tree) Rank(key string) int {
+	return t.t.rank(stringKey(key))
+}
+
+// Returns all keys in the symbol table in byte order
+func (t *Tree) Keys() []string {
+	var res []string
+	t.t.leaves(t.t.root, 0, t.Size(), func(n *node[stringKey]) {
+		res = append(res, string(n.key))
+	})
+	return res
+}
+
+// Returns all keys in the symbol table in the given range.
+func (t *Tree) RangeKeys(lo string, hi string) []string {
+	var res []string
+	t.t.rangeLeaves(stringKey(lo), stringKey(hi), func(n *node[stringKey]) {
+		res = append(res, string(n.key))
+	})
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (t *Tree) RangeSize(lo string, hi string) int {
+	return t.t.rangeSize(stringKey(lo), stringKey(hi))
+}
+
+// Returns a deep copy of the tree. O(n).
+func (t *Tree) Clone() *Tree {
+	return &Tree{tree[stringKey]{t.t.clone(t.t.root)}}
+}
+
+// Reports whether the two trees hold the same key-value pairs. A crit-bit
+// tree has one shape for a set of keys, so both are compared node for node, O(n).
+func (t *Tree) Equal(other *Tree) bool {
+	return t.t.equal(t.t.root, other.t.root)
+}
+
+// Same as Equal: the keys decide the shape
+func (t *Tree) EqualShape(other *Tree) bool {
+	return t.Equal(other)
+}
+
+// Removes every key
+func (t *Tree) Clear() {
+	t.t.root = nil
+}
+
+func (t *Tree) check() error {
+	return t.t.check()
+}
@@ -0,0 +1,202 @@
+package radixTree
+
+import (
+	"algo/searching/conformance"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	var tree *IntTree = new(IntTree)
+	if tree.Size() != 0 || tree.Ceiling(1) != nil || tree.Floor(1) != nil || tree.Rank(5) != 0 {
+		t.Fail()
+	}
+	tree.Put(1, 3)
+	tree.Put(1, 4)
+	tree.Put(2, 3)
+	tree.Put(4, 5)
+	tree.Put(10, 1)
+	if tree.Size() != 4 || !tree.Contains(1) || tree.Get(1) != 4 || tree.Get(0) != 0 {
+		t.Error("Put Wrong")
+	}
+	if e := tree.Floor(3); e == nil || e.Key() != 2 || e.Val() != 3 {
+		t.Error("Floor Wrong")
+	}
+	if e := tree.Ceiling(3); e == nil || e.Key() != 4 {
+		t.Error("Ceiling Wrong")
+	}
+	if e := tree.Select(2); e == nil || e.Key() != 4 || tree.Rank(4) != 2 || tree.Rank(5) != 3 {
+		t.Error("Select/Rank Wrong")
+	}
+	tree.DeleteMin()
+	tree.DeleteMax()
+	tree.Delete(2)
+	minkey, minval := tree.Min()
+	if tree.Size() != 1 || minkey != 4 || minval != 5 {
+		t.Error("Delete Wrong")
+	}
+}
+
+// negative keys sort before positive ones, down to the extremes
+func Test2(t *testing.T) {
+	tree := NewIntTree()
+	keys := []int{math.MinInt, -1 << 40, -3, -1, 0, 1, 2, 1 << 40, math.MaxInt}
+	for i := len(keys) - 1; i >= 0; i-- {
+		tree.Put(keys[i], i)
+	}
+	if !reflect.DeepEqual(tree.Keys(), keys) || tree.check() != nil {
+		t.Error("Order Wrong", tree.Keys())
+	}
+	if !reflect.DeepEqual(tree.RangeKeys(-5, 1), []int{-3, -1, 0, 1}) || tree.RangeSize(-5, 1) != 4 || tree.RangeSize(1, -5) != 0 {
+		t.Error("Range Wrong")
+	}
+	if e := tree.Floor(-2); e == nil || e.Key() != -3 {
+		t.Error("Floor Wrong")
+	}
+	if e := tree.Ceiling(math.MaxInt - 1); e == nil || e.Key() != math.MaxInt {
+		t.Error("Ceiling Wrong")
+	}
+}
+
+// byte strings sort in byte order, a prefix before its extensions and
+// zero bytes included
+func Test3(t *testing.T) {
+	tree := NewTree()
+	keys := []string{"", "\x00", "\x00\x00", "a", "a\x00", "ab", "abc", "abd", "b", "\xff"}
+	for i := len(keys) - 1; i >= 0; i-- {
+		tree.Put(keys[i], i)
+	}
+	if !reflect.DeepEqual(tree.Keys(), keys) || tree.check() != nil {
+		t.Errorf("Order Wrong %q", tree.Keys())
+	}
+	if !reflect.DeepEqual(tree.RangeKeys("a", "abc"), []string{"a", "a\x00", "ab", "abc"}) {
+		t.Error("Range Wrong")
+	}
+	if e := tree.Floor("abcd"); e == nil || e.Key() != "abc" {
+		t.Error("Floor Wrong")
+	}
+	if e := tree.Ceiling("abcd"); e == nil || e.Key() != "abd" || tree.Rank("abcd") != 7 {
+		t.Error("Ceiling Wrong")
+	}
+	tree.Delete("a")
+	tree.Delete("")
+	if tree.Size() != 8 || tree.Contains("a") || !tree.Contains("a\x00") || tree.check() != nil {
+		t.Error("Delete Wrong")
+	}
+}
+
+// Applies operations decoded from ops to a Tree and to a map, failing as
+// soon as they disagree. Keys are short strings over a two-letter alphabet
+// that includes the zero byte.
+func applyStrings(t *testing.T, ops []byte) {
+	t.Helper()
+	tree := NewTree()
+	ref := make(map[string]int)
+	key := func(b byte) string {
+		s := make([]byte, b%4)
+		for j := range s {
+			s[j] = "\x00a"[b>>(2+j)&1]
+		}
+		return string(s)
+	}
+	for i := 0; i+2 < len(ops); i += 3 {
+		k, other := key(ops[i+1]), key(ops[i+2])
+		switch ops[i] % 4 {
+		case 0:
+			tree.Put(k, int(ops[i+2]))
+			ref[k] = int(ops[i+2])
+		case 1:
+			tree.Delete(k)
+			delete(ref, k)
+		case 2:
+			lo, hi := min(k, other), max(k, other)
+			removed := 0
+			for r := range ref {
+				if lo <= r && r <= hi {
+					delete(ref, r)
+					removed++
+				}
+			}
+			if got := tree.DeleteRange(lo, hi); got != removed {
+				t.Fatalf("op %d: DeleteRange(%q, %q) = %d, want %d", i/3, lo, hi, got, removed)
+			}
+		}
+		var keys []string
+		for r := range ref {
+			keys = append(keys, r)
+		}
+		sort.Strings(keys)
+		rank := sort.SearchStrings(keys, k)
+		if tree.Get(k) != ref[k] || tree.Size() != len(ref) || tree.Rank(k) != rank {
+			t.Fatalf("op %d: Get/Rank(%q) = %d %d, want %d %d", i/3, k, tree.Get(k), tree.Rank(k), ref[k], rank)
+		}
+		lo, hi := min(k, other), max(k, other)
+		var want []string
+		for _, r := range keys {
+			if lo <= r && r <= hi {
+				want = append(want, r)
+			}
+		}
+		if got := tree.RangeKeys(lo, hi); !reflect.DeepEqual(got, want) {
+			t.Fatalf("op %d: RangeKeys(%q, %q) = %q, want %q", i/3, lo, hi, got, want)
+		}
+		if err := tree.check(); err != nil {
+			t.Fatalf("op %d: %v", i/3, err)
+		}
+	}
+}
+
+func Test4(t *testing.T) {
+	for seed := uint64(0); seed < 50; seed++ {
+		r := rand.New(rand.NewPCG(seed, seed))
+		ops := make([]byte, 3*300)
+		for i := range ops {
+			ops[i] = byte(r.UintN(256))
+		}
+		applyStrings(t, ops)
+	}
+}
+
+func Test5(t *testing.T) {
+	tree := NewTree()
+	for i, key := range []string{"abd", "a", "", "abc", "b\x00", "b"} {
+		tree.Put(key, i)
+	}
+	c := tree.Clone()
+	if !c.Equal(tree) || !c.EqualShape(tree) || c.check() != nil {
+		t.Error("Clone Wrong")
+	}
+	c.Put("ab", 9)
+	c.Put("a", 7)
+	if c.Equal(tree) || tree.Contains("ab") || tree.Get("a") != 1 {
+		t.Error("Clone Shares Storage")
+	}
+	// the same pairs put in order give the same tree
+	sorted := NewTree()
+	for _, key := range tree.Keys() {
+		sorted.Put(key, tree.Get(key))
+	}
+	if !sorted.Equal(tree) {
+		t.Error("Equal Wrong")
+	}
+	c.Clear()
+	if c.Size() != 0 || c.Keys() != nil || c.check() != nil {
+		t.Error("Clear Wrong")
+	}
+}
+
+func FuzzTree(f *testing.F) {
+	f.Add([]byte("abcdefghijklmnopqrstuvwxyz"))
+	f.Fuzz(applyStrings)
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapt[*Entry[int]](func() *IntTree { return new(IntTree) }, (*IntTree).check))
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Entry[int]](func() *IntTree { return new(IntTree) }, (*IntTree).check))
+}
@@ -0,0 +1,618 @@
+// A symbol table implemented with a scapegoat tree.
+// Nodes carry no balance information beyond the subtree sizes already kept
+// for Rank and Select. An insertion that lands deeper than log_{1/α}(n)
+// walks back up to the first ancestor whose child outweighs α times its own
+// size, the scapegoat, and rebuilds that subtree perfectly balanced. When
+// deletions shrink the tree below α times its largest size since the last
+// full rebuild, the whole tree is rebuilt. Amortized O(log n) per update,
+// worst case O(log n) per lookup.
+// https://people.csail.mit.edu/rivest/pubs/GR93.pdf
+package scapegoatTree
+
+import (
+	"fmt"
+	"math"
+
+	"algo/utils"
+)
+
+// The α used by the zero value: larger values rebuild less often but allow taller trees
+const DefaultAlpha = 0.7
+
+type Node struct {
+	key         int
+	val         int
+	size        int
+	left, right *Node
+}
+
+// Returns the key stored in the node
+func (n *Node) Key() int {
+	return n.key
+}
+
+// Returns the value stored in the node
+func (n *Node) Val() int {
+	return n.val
+}
+
+// Returns the left child, nil if there is none
+func (n *Node) Left() *Node {
+	return n.left
+}
+
+// Returns the right child, nil if there is none
+func (n *Node) Right() *Node {
+	return n.right
+}
+
+// The struct represents an ordered symbol table of int key-value pairs.
+// The zero value is an empty tree using DefaultAlpha.
+type ScapegoatTree struct {
+	root    *Node
+	alpha   float64
+	maxSize int // the largest size since the whole tree was last rebuilt
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+}
+
+// Returns an empty tree with the given α, which must be in (0.5, 1)
+func New(alpha float64) *ScapegoatTree {
+	if !(alpha > 0.5 && alpha < 1) {
+		panic("scapegoatTree: alpha must be in (0.5, 1)")
+	}
+	return &ScapegoatTree{alpha: alpha}
+}
+
+// Returns the α of the tree, DefaultAlpha for the zero value
+func (t *ScapegoatTree) weight() float64 {
+	if t.alpha == 0 {
+		return DefaultAlpha
+	}
+	return t.alpha
+}
+
+// Returns the deepest a node may be in an α-height-balanced tree of n nodes,
+// that is floor(log_{1/α}(n))
+func (t *ScapegoatTree) maxDepth(n int) int {
+	return int(math.Floor(math.Log(float64(n)) / math.Log(1/t.weight())))
+}
+
+// have such a helper function to avoid visiting nil node
+func (t *ScapegoatTree) size(node *Node) int {
+	if node == nil {
+		return 0
+	} else {
+		return node.size
+	}
+}
+
+// Returns an empty tree using DefaultAlpha, ordered by compare
+func NewWithComparator(compare utils.Comparator) *ScapegoatTree {
+	return &ScapegoatTree{comparator: compare}
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *ScapegoatTree) Size() int {
+	return t.size(t.root)
+}
+
+// Appends the nodes of the subtree to res in key order
+func (t *ScapegoatTree) flatten(node *Node, res []*Node) []*Node {
+	if node == nil {
+		return res
+	}
+	res = t.flatten(node.left, res)
+	res = append(res, node)
+	return t.flatten(node.right, res)
+}
+
+// Links the sorted nodes into a perfectly balanced subtree and returns its root
+func (t *ScapegoatTree) build(nodes []*Node) *Node {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	node := nodes[mid]
+	node.left = t.build(nodes[:mid])
+	node.right = t.build(nodes[mid+1:])
+	node.size = len(nodes)
+	return node
+}
+
+// Rebuilds the subtree perfectly balanced and returns its new root
+func (t *ScapegoatTree) rebuild(node *Node) *Node {
+	return t.build(t.flatten(node, make([]*Node, 0, t.size(node))))
+}
+
+// Returns the node by key
+func (t *ScapegoatTree) get(n *Node, key int) *Node {
+	if n == nil {
+		return nil
+	} else {
+		cmp := t.comparator.Compare(key, n.key)
+		if cmp == 0 {
+			return n
+		} else if cmp < 0 {
+			return t.get(n.left, key)
+		} else {
+			return t.get(n.right, key)
+		}
+	}
+}
+
+// Get value by key, return 0 if not exist
+func (t *ScapegoatTree) Get(key int) int {
+	n := t.get(t.root, key)
+	if n != nil {
+		return n.val
+	} else {
+		return 0
+	}
+}
+
+// Return true if the key exists in the symbol table
+func (t *ScapegoatTree) Contains(key int) bool {
+	n := t.get(t.root, key)
+	return n != nil
+}
+
+// Inserts the new key at depth, the depth of node. Returns the new root of
+// the subtree, and whether the new node is too deep and no scapegoat has
+// been rebuilt yet.
+func (t *ScapegoatTree) put(node *Node, key int, val int, depth int) (*Node, bool) {
+	if node == nil {
+		return &Node{key: key, val: val, size: 1}, depth > t.maxDepth(t.Size()+1)
+	}
+	var deep bool
+	var child *Node
+	if t.comparator.Compare(key, node.key) < 0 {
+		node.left, deep = t.put(node.left, key, val, depth+1)
+		child = node.left
+	} else {
+		node.right, deep = t.put(node.right, key, val, depth+1)
+		child = node.right
+	}
+	node.size++
+	if deep && float64(child.size) > t.weight()*float64(node.size) {
+		return t.rebuild(node), false
+	}
+	return node, deep
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *ScapegoatTree) Put(key int, val int) {
+	if n := t.get(t.root, key); n != nil {
+		n.val = val
+		return
+	}
+	t.root, _ = t.put(t.root, key, val, 0)
+	t.maxSize = max(t.maxSize, t.Size())
+}
+
+func (t *ScapegoatTree) deleteMin(node *Node) *Node {
+	if node.left == nil {
+		return node.right
+	}
+	node.left = t.deleteMin(node.left)
+	node.size--
+	return node
+}
+
+// Hibbard deletion, the key must be in the subtree
+func (t *ScapegoatTree) delete(node *Node, key int) *Node {
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp < 0 {
+		node.left = t.delete(node.left, key)
+	} else if cmp > 0 {
+		node.right = t.delete(node.right, key)
+	} else {
+		if node.left == nil {
+			return node.right
+		}
+		if node.right == nil {
+			return node.left
+		}
+		successor := t.findMin(node.right)
+		successor.right = t.deleteMin(node.right)
+		successor.left = node.left
+		node = successor
+	}
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+	return node
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *ScapegoatTree) Delete(key int) {
+	if !t.Contains(key) {
+		return
+	}
+	t.root = t.delete(t.root, key)
+	if float64(t.Size()) < t.weight()*float64(t.maxSize) {
+		t.root = t.rebuild(t.root)
+		t.maxSize = t.Size()
+	}
+}
+
+func (t *ScapegoatTree) findMin(node *Node) *Node {
+	if node == nil {
+		return node
+	}
+
+	if node.left != nil {
+		return t.findMin(node.left)
+	}
+	return node
+}
+
+func (t *ScapegoatTree) findMax(node *Node) *Node {
+	if node == nil {
+		return node
+	}
+
+	if node.right != nil {
+		return t.findMax(node.right)
+	}
+	return node
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (t *ScapegoatTree) DeleteMin() {
+	if t.Size() == 0 {
+		return
+	}
+	t.Delete(t.findMin(t.root).key)
+}
+
+// Removes the largest key and associated value from the symbol table
+func (t *ScapegoatTree) DeleteMax() {
+	if t.Size() == 0 {
+		return
+	}
+	t.Delete(t.findMax(t.root).key)
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *ScapegoatTree) Remove(key int) (val int, ok bool) {
+	n := t.get(t.root, key)
+	if n == nil {
+		return 0, false
+	}
+	t.Delete(key)
+	return n.val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the tree is empty
+func (t *ScapegoatTree) PopMin() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Min()
+	t.DeleteMin()
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the tree is empty
+func (t *ScapegoatTree) PopMax() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Max()
+	t.DeleteMax()
+	return key, val, true
+}
+
+// Keeps the keys of the subtree less than lo; the rest are dropped
+// whole subtrees at a time
+func (t *ScapegoatTree) keepBelow(node *Node, lo int) *Node {
+	if node == nil {
+		return nil
+	}
+	if t.comparator.Compare(node.key, lo) >= 0 {
+		return t.keepBelow(node.left, lo)
+	}
+	node.right = t.keepBelow(node.right, lo)
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+	return node
+}
+
+// Keeps the keys of the subtree greater than hi
+func (t *ScapegoatTree) keepAbove(node *Node, hi int) *Node {
+	if node == nil {
+		return nil
+	}
+	if t.comparator.Compare(node.key, hi) <= 0 {
+		return t.keepAbove(node.right, hi)
+	}
+	node.left = t.keepAbove(node.left, hi)
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+	return node
+}
+
+// Removes the keys in [lo, hi] from the subtree. Below the topmost node in
+// the range only the paths to lo and hi are walked; the subtrees between
+// them are dropped without a visit.
+func (t *ScapegoatTree) deleteRange(node *Node, lo int, hi int) *Node {
+	if node == nil {
+		return nil
+	}
+	if t.comparator.Compare(node.key, lo) < 0 {
+		node.right = t.deleteRange(node.right, lo, hi)
+	} else if t.comparator.Compare(node.key, hi) > 0 {
+		node.left = t.deleteRange(node.left, lo, hi)
+	} else {
+		left, right := t.keepBelow(node.left, lo), t.keepAbove(node.right, hi)
+		if left == nil {
+			return right
+		}
+		if right == nil {
+			return left
+		}
+		successor := t.findMin(right)
+		successor.right = t.deleteMin(right)
+		successor.left = left
+		node = successor
+	}
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+	return node
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// Trimming never deepens a node, so the tree stays α-height-balanced until
+// it shrinks below α of its largest size and is rebuilt, as after Delete.
+// O(log n) plus the amortized rebuilding, which is O(1) per removed key.
+func (t *ScapegoatTree) DeleteRange(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	size := t.Size()
+	t.root = t.deleteRange(t.root, lo, hi)
+	if float64(t.Size()) < t.weight()*float64(t.maxSize) {
+		t.root = t.rebuild(t.root)
+		t.maxSize = t.Size()
+	}
+	return size - t.Size()
+}
+
+func (t *ScapegoatTree) Min() (key int, val int) {
+	minNode := t.findMin(t.root)
+	return minNode.key, minNode.val
+}
+
+func (t *ScapegoatTree) Max() (key int, val int) {
+	maxNode := t.findMax(t.root)
+	return maxNode.key, maxNode.val
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (t *ScapegoatTree) floor(node *Node, key int) *Node {
+	if node == nil {
+		return node
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp == 0 {
+		return node
+	} else if cmp > 0 {
+		r := t.floor(node.right, key)
+		if r != nil {
+			return r
+		} else {
+			return node
+		}
+	} else {
+		return t.floor(node.left, key)
+	}
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (t *ScapegoatTree) Floor(key int) *Node {
+	return t.floor(t.root, key)
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (t *ScapegoatTree) ceiling(node *Node, key int) *Node {
+	if node == nil {
+		return node
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp == 0 {
+		return node
+	} else if cmp < 0 {
+		r := t.ceiling(node.left, key)
+		if r != nil {
+			return r
+		} else {
+			return node
+		}
+	} else {
+		return t.ceiling(node.right, key)
+	}
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (t *ScapegoatTree) Ceiling(key int) *Node {
+	return t.ceiling(t.root, key)
+}
+
+func (t *ScapegoatTree) selectHelper(node *Node, k int) *Node {
+	if node == nil {
+		return node
+	}
+	if t.size(node.left) == k { // say k = 0, should return the smallest
+		return node
+	} else if t.size(node.left) < k {
+		return t.selectHelper(node.right, k-1-t.size(node.left))
+	} else {
+		return t.selectHelper(node.left, k)
+	}
+}
+
+// Return the node in the symbol table whose rank is k
+func (t *ScapegoatTree) Select(k int) *Node {
+	return t.selectHelper(t.root, k)
+}
+
+func (t *ScapegoatTree) rank(node *Node, key int) int {
+	if node == nil {
+		return 0
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp > 0 {
+		return t.size(node.left) + 1 + t.rank(node.right, key)
+	} else if cmp == 0 {
+		return t.size(node.left)
+	} else {
+		return t.rank(node.left, key)
+	}
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (t *ScapegoatTree) Rank(key int) int {
+	return t.rank(t.root, key)
+}
+
+func (t *ScapegoatTree) keys(node *Node, res *[]int) {
+	if node == nil {
+		return
+	} else {
+		t.keys(node.left, res)
+		*res = append(*res, node.key)
+		t.keys(node.right, res)
+	}
+}
+
+// Returns all keys in the symbol table as an Iterable
+func (t *ScapegoatTree) Keys() []int {
+	var res []int
+	t.keys(t.root, &res)
+	return res
+}
+
+func (t *ScapegoatTree) rangekeys(node *Node, lo int, hi int, res *[]int) {
+	if node == nil {
+		return
+	}
+	if t.comparator.Compare(lo, node.key) > 0 {
+		t.rangekeys(node.right, lo, hi, res)
+	} else if t.comparator.Compare(hi, node.key) < 0 {
+		t.rangekeys(node.left, lo, hi, res)
+	} else {
+		t.rangekeys(node.left, lo, hi, res)
+		*res = append(*res, node.key)
+		t.rangekeys(node.right, lo, hi, res)
+	}
+}
+
+// Returns all keys in the symbol table in the given range.
+func (t *ScapegoatTree) RangeKeys(lo int, hi int) []int {
+	var res []int
+	t.rangekeys(t.root, lo, hi, &res)
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (t *ScapegoatTree) RangeSize(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	if t.Contains(hi) {
+		return t.Rank(hi) - t.Rank(lo) + 1
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
+// Returns a deep copy of the tree with the same shape and sizes, and the
+// same largest size since the last rebuild, so it rebuilds the same way
+// from here on. The copy keeps α and the comparator. O(n).
+func (t *ScapegoatTree) Clone() *ScapegoatTree {
+	return &ScapegoatTree{root: t.clone(t.root), alpha: t.alpha, maxSize: t.maxSize, comparator: t.comparator}
+}
+
+func (t *ScapegoatTree) clone(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.left, copied.right = t.clone(node.left), t.clone(node.right)
+	return &copied
+}
+
+// Pushes node and the chain of its left children onto the stack
+func pushLeft(stack []*Node, node *Node) []*Node {
+	for ; node != nil; node = node.left {
+		stack = append(stack, node)
+	}
+	return stack
+}
+
+// Reports whether the two trees hold the same key-value pairs in the same
+// order, whatever their shapes. Both are walked in order side by side, O(n).
+func (t *ScapegoatTree) Equal(other *ScapegoatTree) bool {
+	if t.Size() != other.Size() {
+		return false
+	}
+	s1, s2 := pushLeft(nil, t.root), pushLeft(nil, other.root)
+	for len(s1) != 0 {
+		a, b := s1[len(s1)-1], s2[len(s2)-1]
+		if a.key != b.key || a.val != b.val {
+			return false
+		}
+		s1 = pushLeft(s1[:len(s1)-1], a.right)
+		s2 = pushLeft(s2[:len(s2)-1], b.right)
+	}
+	return true
+}
+
+// Reports whether the two trees are identical node for node: the same keys
+// and values at the same places. Meant for test assertions, e.g. that a
+// Clone reproduced the tree exactly.
+func (t *ScapegoatTree) EqualShape(other *ScapegoatTree) bool {
+	return t.equalShape(t.root, other.root)
+}
+
+func (t *ScapegoatTree) equalShape(a *Node, b *Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.key == b.key && a.val == b.val && t.equalShape(a.left, b.left) && t.equalShape(a.right, b.right)
+}
+
+// Removes every key; α and the comparator stay
+func (t *ScapegoatTree) Clear() {
+	t.root = nil
+	t.maxSize = 0
+}
+
+// Checks the invariants of the tree: symmetric order, subtree sizes and
+// that no node is deeper than log_{1/α} of the largest size since the last
+// full rebuild
+func (t *ScapegoatTree) check() error {
+	if t.Size() > t.maxSize {
+		return fmt.Errorf("size %d exceeds the recorded maximum %d", t.Size(), t.maxSize)
+	}
+	limit := 0
+	if t.maxSize > 0 {
+		limit = t.maxDepth(t.maxSize)
+	}
+	return t.checkNode(t.root, nil, nil, 0, limit)
+}
+
+// lo and hi bound the keys allowed in the subtree, nil meaning unbounded
+func (t *ScapegoatTree) checkNode(node *Node, lo *int, hi *int, depth int, limit int) error {
+	if node == nil {
+		return nil
+	}
+	if (lo != nil && t.comparator.Compare(node.key, *lo) <= 0) || (hi != nil && t.comparator.Compare(node.key, *hi) >= 0) {
+		return fmt.Errorf("key %d is out of order", node.key)
+	}
+	if node.size != 1+t.size(node.left)+t.size(node.right) {
+		return fmt.Errorf("node %d has size %d", node.key, node.size)
+	}
+	if depth > limit {
+		return fmt.Errorf("node %d at depth %d, deeper than %d", node.key, depth, limit)
+	}
+	if err := t.checkNode(node.left, lo, &node.key, depth+1, limit); err != nil {
+		return err
+	}
+	return t.checkNode(node.right, &node.key, hi, depth+1, limit)
+}
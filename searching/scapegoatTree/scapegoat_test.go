@@ -0,0 +1,91 @@
+package scapegoatTree
+
+import (
+	"algo/searching/conformance"
+	"algo/searching/traversal"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	var tree *ScapegoatTree = new(ScapegoatTree)
+	if tree.Size() != 0 {
+		t.Fail()
+	}
+	tree.Put(1, 3)
+	tree.Put(1, 4)
+	tree.Put(2, 3)
+	tree.Put(4, 5)
+	tree.Put(10, 1)
+	if tree.Size() != 4 || !tree.Contains(1) || tree.Get(1) != 4 || tree.Get(0) != 0 {
+		t.Error("Put Wrong")
+	}
+	if n := tree.Floor(3); n == nil || n.Key() != 2 {
+		t.Error("Floor Wrong")
+	}
+	if n := tree.Ceiling(3); n == nil || n.Key() != 4 {
+		t.Error("Ceiling Wrong")
+	}
+	if n := tree.Select(2); n == nil || n.Key() != 4 || tree.Rank(4) != 2 || tree.Rank(5) != 3 {
+		t.Error("Select/Rank Wrong")
+	}
+	tree.DeleteMin()
+	tree.DeleteMax()
+	tree.Delete(2)
+	minkey, minval := tree.Min()
+	if tree.Size() != 1 || minkey != 4 || minval != 5 {
+		t.Error("Delete Wrong")
+	}
+}
+
+// ascending inserts keep finding scapegoats, so the height stays logarithmic
+func Test2(t *testing.T) {
+	tree := New(0.6)
+	for i := 0; i < 1<<12; i++ {
+		tree.Put(i, i)
+	}
+	if h := traversal.Height(tree.root); h > tree.maxDepth(tree.Size()) {
+		t.Error("Height Too Large " + strconv.Itoa(h))
+	}
+	if err := tree.check(); err != nil {
+		t.Error(err)
+	}
+	// deleting most keys triggers a full rebuild
+	for i := 0; i < 1<<12-10; i++ {
+		tree.Delete(i)
+	}
+	if tree.maxSize >= 1<<12 || traversal.Height(tree.root) > 4 || tree.check() != nil {
+		t.Error("Rebuild Wrong")
+	}
+	if !reflect.DeepEqual(tree.RangeKeys(4093, 5000), []int{4093, 4094, 4095}) {
+		t.Error("Range Wrong")
+	}
+}
+
+func Test3(t *testing.T) {
+	for _, alpha := range []float64{0.5, 1, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Alpha Accepted " + strconv.FormatFloat(alpha, 'f', -1, 64))
+				}
+			}()
+			New(alpha)
+		}()
+	}
+}
+
+func TestConformance(t *testing.T) {
+	for _, alpha := range []float64{0.55, 0.7, 0.9} {
+		conformance.Run(t, conformance.Adapt[*Node](func() *ScapegoatTree { return New(alpha) }, (*ScapegoatTree).check))
+	}
+}
+
+func TestReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Node](func() *ScapegoatTree { return NewWithComparator(conformance.Descending) }, (*ScapegoatTree).check))
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Node](func() *ScapegoatTree { return new(ScapegoatTree) }, (*ScapegoatTree).check))
+}
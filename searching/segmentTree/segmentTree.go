@@ -0,0 +1,196 @@
+// A segment tree over an array: range queries under any monoid, and lazy
+// range assignment and range addition, all in O(log n).
+// Every node holds the aggregate of a contiguous range of the array; an
+// update covering a whole node is recorded there as a pending tag and only
+// pushed to the children when a later operation needs to look inside.
+// https://cp-algorithms.com/data_structures/segment_tree.html
+package segmentTree
+
+import (
+	"cmp"
+)
+
+// The value types a segment tree can add to
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// How values are aggregated. Combine must be associative with Identity as
+// its neutral element. Repeat(v, n) is the aggregate of n copies of v, which
+// lets range updates reach a node's aggregate without visiting its leaves:
+// after assigning v to a range of n values its aggregate is Repeat(v, n),
+// and after adding d to each it is the old aggregate plus Repeat(d, n).
+type Monoid[T any] struct {
+	Identity T
+	Combine  func(a T, b T) T
+	Repeat   func(v T, n int) T
+}
+
+// Range sums
+func Sum[T Number]() Monoid[T] {
+	return Monoid[T]{
+		Combine: func(a T, b T) T { return a + b },
+		Repeat:  func(v T, n int) T { return v * T(n) },
+	}
+}
+
+// Range minimums; inf must be at least every value, e.g. math.MaxInt
+func Min[T cmp.Ordered](inf T) Monoid[T] {
+	return Monoid[T]{
+		Identity: inf,
+		Combine:  func(a T, b T) T { return min(a, b) },
+		Repeat:   func(v T, n int) T { return v },
+	}
+}
+
+// Range maximums; negInf must be at most every value, e.g. math.MinInt
+func Max[T cmp.Ordered](negInf T) Monoid[T] {
+	return Monoid[T]{
+		Identity: negInf,
+		Combine:  func(a T, b T) T { return max(a, b) },
+		Repeat:   func(v T, n int) T { return v },
+	}
+}
+
+// The update pending on a node, not yet pushed to its children:
+// assign every value (if assigned), then add to every value
+type tag[T Number] struct {
+	assigned bool
+	assign   T
+	add      T
+}
+
+// The struct represents an array of n values, indexed from 0.
+type SegmentTree[T Number] struct {
+	m    Monoid[T]
+	n    int
+	agg  []T // agg[1] covers the whole array, the children of i are 2i and 2i+1
+	tags []tag[T]
+}
+
+// Returns a segment tree over a copy of values, built in O(n)
+func New[T Number](m Monoid[T], values []T) *SegmentTree[T] {
+	t := &SegmentTree[T]{m: m, n: len(values)}
+	t.agg = make([]T, 4*max(len(values), 1))
+	t.tags = make([]tag[T], len(t.agg))
+	if len(values) > 0 {
+		t.build(1, 0, len(values), values)
+	}
+	return t
+}
+
+func (t *SegmentTree[T]) build(node int, l int, r int, values []T) {
+	if r-l == 1 {
+		t.agg[node] = values[l]
+		return
+	}
+	mid := (l + r) / 2
+	t.build(2*node, l, mid, values)
+	t.build(2*node+1, mid, r, values)
+	t.agg[node] = t.m.Combine(t.agg[2*node], t.agg[2*node+1])
+}
+
+// Returns the number of values
+func (t *SegmentTree[T]) Len() int {
+	return t.n
+}
+
+func (t *SegmentTree[T]) checkRange(lo int, hi int) {
+	if lo < 0 || hi > t.n || lo > hi {
+		panic("segmentTree: range out of bounds")
+	}
+}
+
+// Applies an update to the node covering n values and records it for its children
+func (t *SegmentTree[T]) apply(node int, n int, u tag[T]) {
+	tg := &t.tags[node]
+	if u.assigned {
+		t.agg[node] = t.m.Repeat(u.assign, n)
+		tg.assigned, tg.assign, tg.add = true, u.assign, 0
+	}
+	if u.add != 0 {
+		t.agg[node] += t.m.Repeat(u.add, n)
+		if tg.assigned {
+			tg.assign += u.add
+		} else {
+			tg.add += u.add
+		}
+	}
+}
+
+// Hands the pending update of the node down to its children
+func (t *SegmentTree[T]) push(node int, l int, r int) {
+	if u := t.tags[node]; u.assigned || u.add != 0 {
+		mid := (l + r) / 2
+		t.apply(2*node, mid-l, u)
+		t.apply(2*node+1, r-mid, u)
+		t.tags[node] = tag[T]{}
+	}
+}
+
+// Applies u to the values in [lo, hi), the node covering [l, r)
+func (t *SegmentTree[T]) update(node int, l int, r int, lo int, hi int, u tag[T]) {
+	if hi <= l || r <= lo {
+		return
+	}
+	if lo <= l && r <= hi {
+		t.apply(node, r-l, u)
+		return
+	}
+	t.push(node, l, r)
+	mid := (l + r) / 2
+	t.update(2*node, l, mid, lo, hi, u)
+	t.update(2*node+1, mid, r, lo, hi, u)
+	t.agg[node] = t.m.Combine(t.agg[2*node], t.agg[2*node+1])
+}
+
+// Returns the aggregate of the values in [lo, hi), the node covering [l, r)
+func (t *SegmentTree[T]) query(node int, l int, r int, lo int, hi int) T {
+	if hi <= l || r <= lo {
+		return t.m.Identity
+	}
+	if lo <= l && r <= hi {
+		return t.agg[node]
+	}
+	t.push(node, l, r)
+	mid := (l + r) / 2
+	return t.m.Combine(t.query(2*node, l, mid, lo, hi), t.query(2*node+1, mid, r, lo, hi))
+}
+
+// Returns the aggregate of the values at indices [lo, hi), Identity if the range is empty
+func (t *SegmentTree[T]) Query(lo int, hi int) T {
+	t.checkRange(lo, hi)
+	if lo == hi {
+		return t.m.Identity
+	}
+	return t.query(1, 0, t.n, lo, hi)
+}
+
+// Returns the value at index i
+func (t *SegmentTree[T]) Get(i int) T {
+	t.checkRange(i, i+1)
+	return t.query(1, 0, t.n, i, i+1)
+}
+
+// Sets every value at indices [lo, hi) to val
+func (t *SegmentTree[T]) Assign(lo int, hi int, val T) {
+	t.checkRange(lo, hi)
+	if lo < hi {
+		t.update(1, 0, t.n, lo, hi, tag[T]{assigned: true, assign: val})
+	}
+}
+
+// Sets the value at index i
+func (t *SegmentTree[T]) Set(i int, val T) {
+	t.Assign(i, i+1, val)
+}
+
+// Adds delta to every value at indices [lo, hi)
+func (t *SegmentTree[T]) Add(lo int, hi int, delta T) {
+	t.checkRange(lo, hi)
+	if lo < hi {
+		t.update(1, 0, t.n, lo, hi, tag[T]{add: delta})
+	}
+}
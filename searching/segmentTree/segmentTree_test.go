@@ -0,0 +1,110 @@
+package segmentTree
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	sum := New(Sum[int](), []int{5, 1, 4, 2, 3})
+	if sum.Query(0, 5) != 15 || sum.Query(1, 3) != 5 || sum.Query(2, 2) != 0 || sum.Get(3) != 2 {
+		t.Error("Sum Wrong")
+	}
+	sum.Add(1, 4, 10)
+	sum.Assign(3, 5, 1)
+	// 5 11 14 1 1
+	if sum.Query(0, 5) != 32 || sum.Query(2, 4) != 15 || sum.Get(1) != 11 {
+		t.Error("Update Wrong")
+	}
+	mins := New(Min(math.MaxInt), []int{5, 1, 4, 2, 3})
+	if mins.Query(0, 5) != 1 || mins.Query(2, 5) != 2 || mins.Query(0, 0) != math.MaxInt {
+		t.Error("Min Wrong")
+	}
+	mins.Add(0, 2, -2)
+	mins.Set(4, -7)
+	if mins.Query(0, 2) != -1 || mins.Query(0, 5) != -7 {
+		t.Error("Min Update Wrong")
+	}
+}
+
+// random updates and queries against a plain slice, for every monoid
+func Test2(t *testing.T) {
+	monoids := []struct {
+		name string
+		m    Monoid[int]
+		agg  func([]int) int
+	}{
+		{"Sum", Sum[int](), func(s []int) int {
+			res := 0
+			for _, v := range s {
+				res += v
+			}
+			return res
+		}},
+		{"Min", Min(math.MaxInt), func(s []int) int {
+			res := math.MaxInt
+			for _, v := range s {
+				res = min(res, v)
+			}
+			return res
+		}},
+		{"Max", Max(math.MinInt), func(s []int) int {
+			res := math.MinInt
+			for _, v := range s {
+				res = max(res, v)
+			}
+			return res
+		}},
+	}
+	r := rand.New(rand.NewPCG(1, 1))
+	for _, monoid := range monoids {
+		for n := 1; n < 40; n++ {
+			values := make([]int, n)
+			for i := range values {
+				values[i] = r.IntN(20) - 10
+			}
+			tree := New(monoid.m, values)
+			for op := 0; op < 200; op++ {
+				lo := r.IntN(n + 1)
+				hi := lo + r.IntN(n+1-lo)
+				v := r.IntN(20) - 10
+				switch op % 3 {
+				case 0:
+					tree.Add(lo, hi, v)
+					for i := lo; i < hi; i++ {
+						values[i] += v
+					}
+				case 1:
+					tree.Assign(lo, hi, v)
+					for i := lo; i < hi; i++ {
+						values[i] = v
+					}
+				}
+				lo = r.IntN(n + 1)
+				hi = lo + r.IntN(n+1-lo)
+				if got, want := tree.Query(lo, hi), monoid.agg(values[lo:hi]); got != want {
+					t.Fatalf("%s n %d op %d: Query(%d, %d) = %d, want %d", monoid.name, n, op, lo, hi, got, want)
+				}
+			}
+			for i, v := range values {
+				if tree.Get(i) != v {
+					t.Fatalf("%s n %d: Get(%d) = %d, want %d", monoid.name, n, i, tree.Get(i), v)
+				}
+			}
+		}
+	}
+}
+
+func Test3(t *testing.T) {
+	tree := New(Sum[float64](), nil)
+	if tree.Len() != 0 || tree.Query(0, 0) != 0 {
+		t.Error("Empty Tree Wrong")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Range Out Of Bounds Accepted")
+		}
+	}()
+	tree.Get(0)
+}
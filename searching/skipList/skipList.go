@@ -0,0 +1,513 @@
+// A symbol table implemented with a skip list.
+// Level 0 is a sorted linked list of every key; each higher level skips
+// over a random subset of the level below, so searches take O(log n)
+// expected time. Every link also records its span, the number of level-0
+// steps it jumps over, which makes Rank and Select O(log n) as well.
+// https://en.wikipedia.org/wiki/Skip_list
+// https://github.com/redis/redis/blob/unstable/src/t_zset.c (span counters)
+package skipList
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"algo/utils"
+)
+
+const (
+	DefaultMaxLevel    = 32
+	DefaultProbability = 0.25
+)
+
+type Node struct {
+	key  int
+	val  int
+	next []*Node // next[i] is the successor on level i
+	span []int   // span[i] is how many level-0 steps next[i] skips
+}
+
+// Returns the key stored in the node
+func (n *Node) Key() int {
+	return n.key
+}
+
+// Returns the value stored in the node
+func (n *Node) Val() int {
+	return n.val
+}
+
+// The struct represents an ordered symbol table of int key-value pairs.
+// The zero value is an empty skip list with DefaultMaxLevel,
+// DefaultProbability and seed 0.
+type SkipList struct {
+	head     *Node // sentinel in front of the smallest key, on every level
+	level    int   // number of levels in use
+	n        int
+	maxLevel int
+	p        float64 // chance that a node reaching level i also reaches level i+1
+	rng      *rand.Rand
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+}
+
+// Returns an empty skip list with at most maxLevel levels, where a node is
+// promoted to the next level with probability p. Levels are drawn from a
+// generator seeded with seed, so the same operations build the same list.
+func New(maxLevel int, p float64, seed uint64) *SkipList {
+	if maxLevel < 1 || p <= 0 || p >= 1 {
+		panic("skipList: need maxLevel >= 1 and 0 < p < 1")
+	}
+	return &SkipList{
+		head:     &Node{next: make([]*Node, maxLevel), span: make([]int, maxLevel)},
+		level:    1,
+		maxLevel: maxLevel,
+		p:        p,
+		rng:      rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// Sets up the zero value on first write
+func (t *SkipList) lazyInit() {
+	if t.head == nil {
+		*t = *New(DefaultMaxLevel, DefaultProbability, 0)
+	}
+}
+
+// Returns an empty skip list with the default level settings, ordered by compare
+func NewWithComparator(compare utils.Comparator) *SkipList {
+	return NewConfigWithComparator(DefaultMaxLevel, DefaultProbability, 0, compare)
+}
+
+// Same as New, with the keys ordered by compare
+func NewConfigWithComparator(maxLevel int, p float64, seed uint64, compare utils.Comparator) *SkipList {
+	t := New(maxLevel, p, seed)
+	t.comparator = compare
+	return t
+}
+
+// Draws the level of a new node: 1 plus one for every successful coin flip
+func (t *SkipList) randomLevel() int {
+	level := 1
+	for level < t.maxLevel && t.rng.Float64() < t.p {
+		level++
+	}
+	return level
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *SkipList) Size() int {
+	return t.n
+}
+
+// Returns the last node on level 0 whose key is less than key, or the head.
+// If update is not nil, it receives the last such node on every level and
+// rank the number of level-0 steps from the head to it.
+func (t *SkipList) findLess(key int, update []*Node, rank []int) *Node {
+	x := t.head
+	r := 0
+	for i := t.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && t.comparator.Compare(x.next[i].key, key) < 0 {
+			r += x.span[i]
+			x = x.next[i]
+		}
+		if update != nil {
+			update[i] = x
+			rank[i] = r
+		}
+	}
+	return x
+}
+
+// Returns the node by key
+func (t *SkipList) get(key int) *Node {
+	if t.head == nil {
+		return nil
+	}
+	x := t.findLess(key, nil, nil).next[0]
+	if x != nil && t.comparator.Compare(x.key, key) == 0 {
+		return x
+	}
+	return nil
+}
+
+// Get value by key, return 0 if not exist
+func (t *SkipList) Get(key int) int {
+	if x := t.get(key); x != nil {
+		return x.val
+	}
+	return 0
+}
+
+// Return true if the key exists in the symbol table
+func (t *SkipList) Contains(key int) bool {
+	return t.get(key) != nil
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *SkipList) Put(key int, val int) {
+	t.lazyInit()
+	update := make([]*Node, t.maxLevel)
+	rank := make([]int, t.maxLevel)
+	x := t.findLess(key, update, rank).next[0]
+	if x != nil && t.comparator.Compare(x.key, key) == 0 {
+		x.val = val
+		return
+	}
+	level := t.randomLevel()
+	for i := t.level; i < level; i++ {
+		// a new level starts with a single link from the head past every node
+		update[i] = t.head
+		rank[i] = 0
+		t.head.span[i] = t.n
+	}
+	t.level = max(t.level, level)
+
+	x = &Node{key: key, val: val, next: make([]*Node, level), span: make([]int, level)}
+	for i := 0; i < level; i++ {
+		x.next[i] = update[i].next[i]
+		update[i].next[i] = x
+		// rank[0] - rank[i] steps lie between update[i] and the new node
+		x.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	// the links above the new node now jump over one more step
+	for i := level; i < t.level; i++ {
+		update[i].span[i]++
+	}
+	t.n++
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *SkipList) Delete(key int) {
+	if t.head == nil {
+		return
+	}
+	update := make([]*Node, t.maxLevel)
+	rank := make([]int, t.maxLevel)
+	x := t.findLess(key, update, rank).next[0]
+	if x == nil || t.comparator.Compare(x.key, key) != 0 {
+		return
+	}
+	for i := 0; i < t.level; i++ {
+		if update[i].next[i] == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for t.level > 1 && t.head.next[t.level-1] == nil {
+		t.level--
+	}
+	t.n--
+}
+
+// Returns the node with the smallest key, nil if the list is empty
+func (t *SkipList) findMin() *Node {
+	if t.head == nil {
+		return nil
+	}
+	return t.head.next[0]
+}
+
+// Returns the node with the largest key, nil if the list is empty
+func (t *SkipList) findMax() *Node {
+	if t.head == nil {
+		return nil
+	}
+	x := t.head
+	for i := t.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	if x == t.head {
+		return nil
+	}
+	return x
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (t *SkipList) DeleteMin() {
+	if t.n == 0 {
+		return
+	}
+	t.Delete(t.findMin().key)
+}
+
+// Removes the largest key and associated value from the symbol table
+func (t *SkipList) DeleteMax() {
+	if t.n == 0 {
+		return
+	}
+	t.Delete(t.findMax().key)
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *SkipList) Remove(key int) (val int, ok bool) {
+	x := t.get(key)
+	if x == nil {
+		return 0, false
+	}
+	t.Delete(key)
+	return x.val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the list is empty
+func (t *SkipList) PopMin() (key int, val int, ok bool) {
+	if t.n == 0 {
+		return 0, 0, false
+	}
+	minNode := t.findMin()
+	t.Delete(minNode.key)
+	return minNode.key, minNode.val, true
+}
+
+// Removes the largest key and returns it with its value; false if the list is empty
+func (t *SkipList) PopMax() (key int, val int, ok bool) {
+	if t.n == 0 {
+		return 0, 0, false
+	}
+	maxNode := t.findMax()
+	t.Delete(maxNode.key)
+	return maxNode.key, maxNode.val, true
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// The run of nodes in the range is unlinked on every level at once,
+// O(log n + k) for k removed keys.
+func (t *SkipList) DeleteRange(lo int, hi int) int {
+	if t.head == nil || t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	update := make([]*Node, t.maxLevel)
+	rank := make([]int, t.maxLevel)
+	k := 0
+	for x := t.findLess(lo, update, rank).next[0]; x != nil && t.comparator.Compare(x.key, hi) <= 0; x = x.next[0] {
+		k++
+	}
+	if k == 0 {
+		return 0
+	}
+	for i := 0; i < t.level; i++ {
+		// steps from update[i] to the first node past the range on level i
+		steps := update[i].span[i]
+		z := update[i].next[i]
+		for z != nil && t.comparator.Compare(z.key, hi) <= 0 {
+			steps += z.span[i]
+			z = z.next[i]
+		}
+		update[i].next[i] = z
+		update[i].span[i] = steps - k
+	}
+	for t.level > 1 && t.head.next[t.level-1] == nil {
+		t.level--
+	}
+	t.n -= k
+	return k
+}
+
+func (t *SkipList) Min() (key int, val int) {
+	minNode := t.findMin()
+	return minNode.key, minNode.val
+}
+
+func (t *SkipList) Max() (key int, val int) {
+	maxNode := t.findMax()
+	return maxNode.key, maxNode.val
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (t *SkipList) Floor(key int) *Node {
+	if t.head == nil {
+		return nil
+	}
+	x := t.head
+	for i := t.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && t.comparator.Compare(x.next[i].key, key) <= 0 {
+			x = x.next[i]
+		}
+	}
+	if x == t.head {
+		return nil
+	}
+	return x
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (t *SkipList) Ceiling(key int) *Node {
+	if t.head == nil {
+		return nil
+	}
+	return t.findLess(key, nil, nil).next[0]
+}
+
+// Return the node in the symbol table whose rank is k
+func (t *SkipList) Select(k int) *Node {
+	if k < 0 || k >= t.n {
+		return nil
+	}
+	// the node of rank k is k+1 level-0 steps away from the head
+	x := t.head
+	traversed := 0
+	for i := t.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && traversed+x.span[i] <= k+1 {
+			traversed += x.span[i]
+			x = x.next[i]
+		}
+		if traversed == k+1 {
+			return x
+		}
+	}
+	return nil
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (t *SkipList) Rank(key int) int {
+	if t.head == nil {
+		return 0
+	}
+	x := t.head
+	rank := 0
+	for i := t.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && t.comparator.Compare(x.next[i].key, key) < 0 {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+	}
+	return rank
+}
+
+// Returns all keys in the symbol table as an Iterable
+func (t *SkipList) Keys() []int {
+	var res []int
+	for x := t.findMin(); x != nil; x = x.next[0] {
+		res = append(res, x.key)
+	}
+	return res
+}
+
+// Returns all keys in the symbol table in the given range.
+func (t *SkipList) RangeKeys(lo int, hi int) []int {
+	var res []int
+	for x := t.Ceiling(lo); x != nil && t.comparator.Compare(x.key, hi) <= 0; x = x.next[0] {
+		res = append(res, x.key)
+	}
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (t *SkipList) RangeSize(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	if t.Contains(hi) {
+		return t.Rank(hi) - t.Rank(lo) + 1
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
+// Returns a deep copy of the list with the same levels and spans, and the
+// same settings and comparator. The copy draws later levels from a
+// generator seeded by t's. O(n) expected time.
+func (t *SkipList) Clone() *SkipList {
+	if t.head == nil {
+		return &SkipList{comparator: t.comparator}
+	}
+	c := *t
+	c.rng = rand.New(rand.NewPCG(t.rng.Uint64(), t.rng.Uint64()))
+	c.head = &Node{next: make([]*Node, len(t.head.next)), span: slices.Clone(t.head.span)}
+	// last[i] is the latest copy on level i, which the next one on i follows
+	last := make([]*Node, len(t.head.next))
+	for i := range last {
+		last[i] = c.head
+	}
+	for x := t.head.next[0]; x != nil; x = x.next[0] {
+		copied := &Node{key: x.key, val: x.val, next: make([]*Node, len(x.next)), span: slices.Clone(x.span)}
+		for i := range copied.next {
+			last[i].next[i] = copied
+			last[i] = copied
+		}
+	}
+	return &c
+}
+
+// Reports whether the two lists hold the same key-value pairs, walking
+// level 0 of both side by side, O(n)
+func (t *SkipList) Equal(other *SkipList) bool {
+	if t.n != other.n {
+		return false
+	}
+	for a, b := t.findMin(), other.findMin(); a != nil; a, b = a.next[0], b.next[0] {
+		if a.key != b.key || a.val != b.val {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether the two lists are identical node for node: the same
+// keys and values reaching the same levels. Meant for test assertions,
+// e.g. that a Clone reproduced the list exactly.
+func (t *SkipList) EqualShape(other *SkipList) bool {
+	if t.n != other.n {
+		return false
+	}
+	for a, b := t.findMin(), other.findMin(); a != nil; a, b = a.next[0], b.next[0] {
+		if a.key != b.key || a.val != b.val || len(a.next) != len(b.next) {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes every key; the settings, generator and comparator stay
+func (t *SkipList) Clear() {
+	if t.head == nil {
+		return
+	}
+	clear(t.head.next)
+	clear(t.head.span)
+	t.level, t.n = 1, 0
+}
+
+// Checks the invariants of the skip list: level 0 is sorted and holds n
+// keys, every level is a sublist of the one below, and every span is the
+// distance between the nodes it links
+func (t *SkipList) check() error {
+	if t.head == nil {
+		if t.n != 0 {
+			return fmt.Errorf("no head but %d pairs", t.n)
+		}
+		return nil
+	}
+	pos := map[*Node]int{t.head: 0}
+	for x := t.head.next[0]; x != nil; x = x.next[0] {
+		pos[x] = len(pos)
+		if x.next[0] != nil && t.comparator.Compare(x.key, x.next[0].key) >= 0 {
+			return fmt.Errorf("key %d is out of order", x.key)
+		}
+	}
+	if len(pos)-1 != t.n {
+		return fmt.Errorf("%d pairs are counted as %d", len(pos)-1, t.n)
+	}
+	for i := 0; i < t.maxLevel; i++ {
+		if i >= t.level {
+			if t.head.next[i] != nil {
+				return fmt.Errorf("level %d is used above level %d", i, t.level)
+			}
+			continue
+		}
+		for x := t.head; x.next[i] != nil; x = x.next[i] {
+			p, ok := pos[x.next[i]]
+			if !ok {
+				return fmt.Errorf("level %d links to a node missing from level 0", i)
+			}
+			if x.span[i] != p-pos[x] {
+				return fmt.Errorf("link from %d on level %d has span %d, want %d", x.key, i, x.span[i], p-pos[x])
+			}
+		}
+	}
+	return nil
+}
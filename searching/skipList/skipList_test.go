@@ -0,0 +1,129 @@
+package skipList
+
+import (
+	"algo/searching/conformance"
+	"reflect"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	var list *SkipList = new(SkipList)
+	if list.Size() != 0 || list.Floor(1) != nil || list.Select(0) != nil {
+		t.Fail()
+	}
+	list.Put(1, 3)
+	list.Put(1, 4)
+	list.Put(2, 3)
+	list.Put(4, 5)
+	list.Put(10, 1)
+	if list.Size() != 4 || !list.Contains(1) || list.Get(1) != 4 || list.Get(0) != 0 {
+		t.Error("Put Wrong")
+	}
+	if n := list.Floor(3); n == nil || n.Key() != 2 {
+		t.Error("Floor Wrong")
+	}
+	if n := list.Ceiling(3); n == nil || n.Key() != 4 {
+		t.Error("Ceiling Wrong")
+	}
+	if n := list.Select(2); n == nil || n.Key() != 4 || list.Rank(4) != 2 || list.Rank(5) != 3 {
+		t.Error("Select/Rank Wrong")
+	}
+	if !reflect.DeepEqual(list.RangeKeys(2, 9), []int{2, 4}) || list.RangeSize(2, 10) != 3 {
+		t.Error("Range Wrong")
+	}
+	list.DeleteMin()
+	list.DeleteMax()
+	list.Delete(2)
+	minkey, minval := list.Min()
+	if list.Size() != 1 || minkey != 4 || minval != 5 {
+		t.Error("Delete Wrong")
+	}
+}
+
+// a single level degenerates into a sorted linked list, which must still work
+func Test2(t *testing.T) {
+	list := New(1, 0.5, 0)
+	for i := 10; i > 0; i-- {
+		list.Put(i, i)
+	}
+	if list.level != 1 || list.Select(9).Key() != 10 || list.Rank(5) != 4 || list.check() != nil {
+		t.Error("Single Level Wrong")
+	}
+}
+
+// the same seed and operations give the same levels
+func Test3(t *testing.T) {
+	levels := func(seed uint64) []int {
+		list := New(16, 0.5, seed)
+		for i := 0; i < 100; i++ {
+			list.Put(i, i)
+		}
+		var res []int
+		for x := list.head.next[0]; x != nil; x = x.next[0] {
+			res = append(res, len(x.next))
+		}
+		return res
+	}
+	if !reflect.DeepEqual(levels(3), levels(3)) {
+		t.Error("Seeding Wrong")
+	}
+	if reflect.DeepEqual(levels(3), levels(4)) {
+		t.Error("Seed Ignored")
+	}
+}
+
+func Test4(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Bad Probability Accepted")
+		}
+	}()
+	New(8, 1, 0)
+}
+
+func Test5(t *testing.T) {
+	list := New(16, 0.5, 5)
+	for i := 0; i < 1000; i++ {
+		list.Put(i, i)
+	}
+	if list.DeleteRange(100, 899) != 800 || list.Size() != 200 || list.check() != nil {
+		t.Error("DeleteRange Wrong")
+	}
+	if n := list.Select(100); n == nil || n.Key() != 900 || list.Rank(950) != 150 {
+		t.Error("Select/Rank Wrong")
+	}
+	if list.DeleteRange(0, 2000) != 200 || list.Size() != 0 || list.check() != nil {
+		t.Error("DeleteRange Wrong")
+	}
+	if _, _, ok := list.PopMax(); ok {
+		t.Error("PopMax Wrong")
+	}
+}
+
+// the comparator does not cost the level settings
+func Test6(t *testing.T) {
+	list := NewConfigWithComparator(3, 0.5, 6, conformance.Descending)
+	for i := 0; i < 200; i++ {
+		list.Put(i, i)
+	}
+	if list.maxLevel != 3 || list.level > 3 || list.p != 0.5 || list.check() != nil {
+		t.Error("Config Wrong")
+	}
+	if k, _ := list.Min(); k != 199 || list.Rank(150) != 49 {
+		t.Error("Order Wrong")
+	}
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapt[*Node](func() *SkipList { return new(SkipList) }, (*SkipList).check))
+	// a high promotion rate exercises many levels on small tables
+	conformance.Run(t, conformance.Adapt[*Node](func() *SkipList { return New(6, 0.75, 1) }, (*SkipList).check))
+}
+
+func TestReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Node](func() *SkipList { return NewWithComparator(conformance.Descending) }, (*SkipList).check))
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Node](func() *SkipList { return New(6, 0.5, 1) }, (*SkipList).check))
+}
@@ -0,0 +1,512 @@
+// A symbol table implemented with a top-down splay tree.
+// Every access moves the key it touches to the root, so recently used keys
+// are found near the top; operations take O(log n) amortized time.
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/SplayBST.java.html
+// https://www.link.cs.cmu.edu/splay/ (top-down splaying, Sleator)
+package splayTree
+
+import (
+	"fmt"
+
+	"algo/utils"
+)
+
+type Node struct {
+	key         int
+	val         int
+	size        int
+	left, right *Node
+}
+
+// Returns the key stored in the node
+func (n *Node) Key() int {
+	return n.key
+}
+
+// Returns the value stored in the node
+func (n *Node) Val() int {
+	return n.val
+}
+
+// The struct represents an ordered symbol table of int key-value pairs.
+type SplayTree struct {
+	root *Node
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+}
+
+// have such a helper function to avoid visiting nil node
+func (t *SplayTree) size(node *Node) int {
+	if node == nil {
+		return 0
+	} else {
+		return node.size
+	}
+}
+
+// Returns an empty tree ordered by compare
+func NewWithComparator(compare utils.Comparator) *SplayTree {
+	return &SplayTree{comparator: compare}
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *SplayTree) Size() int {
+	return t.size(t.root)
+}
+
+/**
+ * Splays the subtree rooted at node top-down: the node holding key, or the
+ * last node on the search path for key, becomes the new root.
+ * On the way down, the nodes passed over are linked into a left tree (keys
+ * smaller than key) and a right tree (keys larger than key), which become
+ * the children of the new root at the end. Their sizes are only right once
+ * the trees are complete, so the linked nodes are remembered and resized
+ * bottom-up afterwards.
+ */
+func (t *SplayTree) splay(node *Node, key int) *Node {
+	if node == nil {
+		return nil
+	}
+	// header.right is the root of the left tree, header.left of the right tree
+	var header Node
+	l, r := &header, &header
+	var leftSpine, rightSpine []*Node
+	for {
+		cmp := t.comparator.Compare(key, node.key)
+		if cmp < 0 {
+			if node.left == nil {
+				break
+			}
+			if t.comparator.Compare(key, node.left.key) < 0 {
+				// zig-zig: rotate right first
+				child := node.left
+				node.left = child.right
+				child.right = node
+				node.size = 1 + t.size(node.left) + t.size(node.right)
+				node = child
+				if node.left == nil {
+					break
+				}
+			}
+			// link right
+			r.left = node
+			r = node
+			rightSpine = append(rightSpine, node)
+			node = node.left
+		} else if cmp > 0 {
+			if node.right == nil {
+				break
+			}
+			if t.comparator.Compare(key, node.right.key) > 0 {
+				// zag-zag: rotate left first
+				child := node.right
+				node.right = child.left
+				child.left = node
+				node.size = 1 + t.size(node.left) + t.size(node.right)
+				node = child
+				if node.right == nil {
+					break
+				}
+			}
+			// link left
+			l.right = node
+			l = node
+			leftSpine = append(leftSpine, node)
+			node = node.right
+		} else {
+			break
+		}
+	}
+	// assemble
+	l.right = node.left
+	r.left = node.right
+	node.left = header.right
+	node.right = header.left
+
+	// the nodes linked last are the deepest ones
+	for i := len(leftSpine) - 1; i >= 0; i-- {
+		n := leftSpine[i]
+		n.size = 1 + t.size(n.left) + t.size(n.right)
+	}
+	for i := len(rightSpine) - 1; i >= 0; i-- {
+		n := rightSpine[i]
+		n.size = 1 + t.size(n.left) + t.size(n.right)
+	}
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+	return node
+}
+
+// Get value by key, return 0 if not exist
+func (t *SplayTree) Get(key int) int {
+	t.root = t.splay(t.root, key)
+	if t.root != nil && t.comparator.Compare(key, t.root.key) == 0 {
+		return t.root.val
+	}
+	return 0
+}
+
+// Return true if the key exists in the symbol table
+func (t *SplayTree) Contains(key int) bool {
+	t.root = t.splay(t.root, key)
+	return t.root != nil && t.comparator.Compare(key, t.root.key) == 0
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *SplayTree) Put(key int, val int) {
+	if t.root == nil {
+		t.root = &Node{key, val, 1, nil, nil}
+		return
+	}
+	t.root = t.splay(t.root, key)
+	cmp := t.comparator.Compare(key, t.root.key)
+	if cmp == 0 {
+		t.root.val = val
+		return
+	}
+	// the old root is the predecessor or the successor of key
+	node := &Node{key, val, 1, nil, nil}
+	if cmp < 0 {
+		node.left = t.root.left
+		node.right = t.root
+		t.root.left = nil
+	} else {
+		node.right = t.root.right
+		node.left = t.root
+		t.root.right = nil
+	}
+	t.root.size = 1 + t.size(t.root.left) + t.size(t.root.right)
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+	t.root = node
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *SplayTree) Delete(key int) {
+	t.root = t.splay(t.root, key)
+	if t.root == nil || t.comparator.Compare(key, t.root.key) != 0 {
+		return
+	}
+	if t.root.left == nil {
+		t.root = t.root.right
+		return
+	}
+	// every key on the left is smaller than key, so splaying for key
+	// brings the largest of them up, leaving its right link free
+	right := t.root.right
+	t.root = t.splay(t.root.left, key)
+	t.root.right = right
+	t.root.size = 1 + t.size(t.root.left) + t.size(t.root.right)
+}
+
+func (t *SplayTree) findMin(node *Node) *Node {
+	if node == nil {
+		return node
+	}
+
+	if node.left != nil {
+		return t.findMin(node.left)
+	}
+	return node
+}
+
+func (t *SplayTree) findMax(node *Node) *Node {
+	if node == nil {
+		return node
+	}
+
+	if node.right != nil {
+		return t.findMax(node.right)
+	}
+	return node
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (t *SplayTree) DeleteMin() {
+	if t.Size() == 0 {
+		return
+	}
+	t.root = t.splay(t.root, t.findMin(t.root).key)
+	t.root = t.root.right
+}
+
+// Removes the largest key and associated value from the symbol table
+func (t *SplayTree) DeleteMax() {
+	if t.Size() == 0 {
+		return
+	}
+	t.root = t.splay(t.root, t.findMax(t.root).key)
+	t.root = t.root.left
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *SplayTree) Remove(key int) (val int, ok bool) {
+	if !t.Contains(key) {
+		return 0, false
+	}
+	// Contains splayed the key to the root
+	val = t.root.val
+	t.Delete(key)
+	return val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the tree is empty
+func (t *SplayTree) PopMin() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Min()
+	t.DeleteMin()
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the tree is empty
+func (t *SplayTree) PopMax() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Max()
+	t.DeleteMax()
+	return key, val, true
+}
+
+// Splits the subtree into the keys before key and the rest, where before
+// means less than key, or less than or equal to it if orEqual is set.
+// After splaying key, the root is key or a neighbour of it, so one of its
+// links is the cut.
+func (t *SplayTree) split(node *Node, key int, orEqual bool) (*Node, *Node) {
+	if node == nil {
+		return nil, nil
+	}
+	node = t.splay(node, key)
+	cmp := t.comparator.Compare(node.key, key)
+	if cmp < 0 || (orEqual && cmp == 0) {
+		right := node.right
+		node.right = nil
+		node.size = 1 + t.size(node.left)
+		return node, right
+	}
+	left := node.left
+	node.left = nil
+	node.size = 1 + t.size(node.right)
+	return left, node
+}
+
+// Joins two subtrees where every key of left is less than every key of
+// right: the largest key of left is splayed up, leaving its right link free
+func (t *SplayTree) join(left *Node, right *Node) *Node {
+	if left == nil {
+		return right
+	}
+	left = t.splay(left, t.findMax(left).key)
+	left.right = right
+	left.size = 1 + t.size(left.left) + t.size(right)
+	return left
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// Splitting at lo and at hi cuts the range out as one subtree, which is
+// dropped whole: O(log n) amortized however many keys go.
+func (t *SplayTree) DeleteRange(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	left, rest := t.split(t.root, lo, false)
+	middle, right := t.split(rest, hi, true)
+	t.root = t.join(left, right)
+	return t.size(middle)
+}
+
+func (t *SplayTree) Min() (key int, val int) {
+	t.root = t.splay(t.root, t.findMin(t.root).key)
+	return t.root.key, t.root.val
+}
+
+func (t *SplayTree) Max() (key int, val int) {
+	t.root = t.splay(t.root, t.findMax(t.root).key)
+	return t.root.key, t.root.val
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (t *SplayTree) Floor(key int) *Node {
+	t.root = t.splay(t.root, key)
+	if t.root == nil || t.comparator.Compare(t.root.key, key) <= 0 {
+		return t.root
+	}
+	// the root is the successor of key, so the floor is its predecessor
+	return t.findMax(t.root.left)
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (t *SplayTree) Ceiling(key int) *Node {
+	t.root = t.splay(t.root, key)
+	if t.root == nil || t.comparator.Compare(t.root.key, key) >= 0 {
+		return t.root
+	}
+	// the root is the predecessor of key, so the ceiling is its successor
+	return t.findMin(t.root.right)
+}
+
+func (t *SplayTree) selectHelper(node *Node, k int) *Node {
+	if node == nil {
+		return node
+	}
+	if t.size(node.left) == k { // say k = 0, should return the smallest
+		return node
+	} else if t.size(node.left) < k {
+		return t.selectHelper(node.right, k-1-t.size(node.left))
+	} else {
+		return t.selectHelper(node.left, k)
+	}
+}
+
+// Return the node in the symbol table whose rank is k, splayed to the root
+func (t *SplayTree) Select(k int) *Node {
+	node := t.selectHelper(t.root, k)
+	if node != nil {
+		t.root = t.splay(t.root, node.key)
+	}
+	return node
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (t *SplayTree) Rank(key int) int {
+	t.root = t.splay(t.root, key)
+	if t.root == nil {
+		return 0
+	}
+	if t.comparator.Compare(t.root.key, key) < 0 {
+		return t.size(t.root.left) + 1
+	}
+	return t.size(t.root.left)
+}
+
+func (t *SplayTree) keys(node *Node, res *[]int) {
+	if node == nil {
+		return
+	} else {
+		t.keys(node.left, res)
+		*res = append(*res, node.key)
+		t.keys(node.right, res)
+	}
+}
+
+// Returns all keys in the symbol table as an Iterable
+func (t *SplayTree) Keys() []int {
+	var res []int
+	t.keys(t.root, &res)
+	return res
+}
+
+func (t *SplayTree) rangekeys(node *Node, lo int, hi int, res *[]int) {
+	if node == nil {
+		return
+	}
+	if t.comparator.Compare(lo, node.key) > 0 {
+		t.rangekeys(node.right, lo, hi, res)
+	} else if t.comparator.Compare(hi, node.key) < 0 {
+		t.rangekeys(node.left, lo, hi, res)
+	} else {
+		t.rangekeys(node.left, lo, hi, res)
+		*res = append(*res, node.key)
+		t.rangekeys(node.right, lo, hi, res)
+	}
+}
+
+// Returns all keys in the symbol table in the given range.
+func (t *SplayTree) RangeKeys(lo int, hi int) []int {
+	var res []int
+	t.rangekeys(t.root, lo, hi, &res)
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (t *SplayTree) RangeSize(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	if t.Contains(hi) {
+		return t.Rank(hi) - t.Rank(lo) + 1
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
+// Returns a deep copy of the tree with the same shape and sizes.
+// The copy keeps the comparator. O(n).
+func (t *SplayTree) Clone() *SplayTree {
+	return &SplayTree{root: t.clone(t.root), comparator: t.comparator}
+}
+
+func (t *SplayTree) clone(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.left, copied.right = t.clone(node.left), t.clone(node.right)
+	return &copied
+}
+
+// Pushes node and the chain of its left children onto the stack
+func pushLeft(stack []*Node, node *Node) []*Node {
+	for ; node != nil; node = node.left {
+		stack = append(stack, node)
+	}
+	return stack
+}
+
+// Reports whether the two trees hold the same key-value pairs in the same
+// order, whatever their shapes. Both are walked in order side by side, O(n).
+func (t *SplayTree) Equal(other *SplayTree) bool {
+	if t.Size() != other.Size() {
+		return false
+	}
+	s1, s2 := pushLeft(nil, t.root), pushLeft(nil, other.root)
+	for len(s1) != 0 {
+		a, b := s1[len(s1)-1], s2[len(s2)-1]
+		if a.key != b.key || a.val != b.val {
+			return false
+		}
+		s1 = pushLeft(s1[:len(s1)-1], a.right)
+		s2 = pushLeft(s2[:len(s2)-1], b.right)
+	}
+	return true
+}
+
+// Reports whether the two trees are identical node for node: the same keys
+// and values at the same places. Meant for test assertions, e.g. that a
+// Clone reproduced the tree exactly.
+func (t *SplayTree) EqualShape(other *SplayTree) bool {
+	return t.equalShape(t.root, other.root)
+}
+
+func (t *SplayTree) equalShape(a *Node, b *Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.key == b.key && a.val == b.val && t.equalShape(a.left, b.left) && t.equalShape(a.right, b.right)
+}
+
+// Removes every key; the comparator stays
+func (t *SplayTree) Clear() {
+	t.root = nil
+}
+
+// Checks the invariants of the tree: symmetric order and subtree sizes
+func (t *SplayTree) check() error {
+	return t.checkNode(t.root, nil, nil)
+}
+
+// lo and hi bound the keys allowed in the subtree, nil meaning unbounded
+func (t *SplayTree) checkNode(node *Node, lo *int, hi *int) error {
+	if node == nil {
+		return nil
+	}
+	if (lo != nil && t.comparator.Compare(node.key, *lo) <= 0) || (hi != nil && t.comparator.Compare(node.key, *hi) >= 0) {
+		return fmt.Errorf("key %d is out of order", node.key)
+	}
+	if node.size != 1+t.size(node.left)+t.size(node.right) {
+		return fmt.Errorf("node %d has size %d", node.key, node.size)
+	}
+	if err := t.checkNode(node.left, lo, &node.key); err != nil {
+		return err
+	}
+	return t.checkNode(node.right, &node.key, hi)
+}
@@ -0,0 +1,80 @@
+package splayTree
+
+import (
+	"algo/searching/conformance"
+	"reflect"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	var tree *SplayTree = new(SplayTree)
+	if tree.Size() != 0 {
+		t.Fail()
+	}
+	tree.Put(1, 3)
+	tree.Put(1, 4)
+	tree.Put(2, 3)
+	tree.Put(4, 5)
+	tree.Put(10, 1)
+	if tree.Size() != 4 || !tree.Contains(1) || tree.Get(1) != 4 || tree.Get(0) != 0 {
+		t.Error("Put Wrong")
+	}
+	if n := tree.Floor(3); n == nil || n.Key() != 2 {
+		t.Error("Floor Wrong")
+	}
+	if n := tree.Ceiling(3); n == nil || n.Key() != 4 {
+		t.Error("Ceiling Wrong")
+	}
+	if tree.Floor(0) != nil || tree.Ceiling(11) != nil {
+		t.Error("Floor/Ceiling Out Of Range Wrong")
+	}
+	if n := tree.Select(2); n == nil || n.Key() != 4 || tree.Rank(4) != 2 || tree.Rank(5) != 3 {
+		t.Error("Select/Rank Wrong")
+	}
+	tree.DeleteMin()
+	tree.DeleteMax()
+	if !reflect.DeepEqual(tree.Keys(), []int{2, 4}) {
+		t.Error("DeleteMin/DeleteMax Wrong")
+	}
+	tree.Delete(2)
+	tree.Delete(3)
+	minkey, minval := tree.Min()
+	if tree.Size() != 1 || minkey != 4 || minval != 5 {
+		t.Error("Delete Wrong")
+	}
+}
+
+func Test2(t *testing.T) {
+	var tree *SplayTree = new(SplayTree)
+	for i := 0; i < 100; i++ {
+		tree.Put(i, i)
+	}
+	// every access brings the key to the root
+	for _, key := range []int{50, 3, 97, 3} {
+		tree.Get(key)
+		if tree.root.key != key {
+			t.Error("Splay Wrong")
+		}
+	}
+	// a missing key brings up its predecessor or successor
+	tree.Delete(60)
+	tree.Get(60)
+	if tree.root.key != 59 && tree.root.key != 61 {
+		t.Error("Splay Missing Key Wrong")
+	}
+	if err := tree.check(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapt[*Node](func() *SplayTree { return new(SplayTree) }, (*SplayTree).check))
+}
+
+func TestReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Node](func() *SplayTree { return NewWithComparator(conformance.Descending) }, (*SplayTree).check))
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Node](func() *SplayTree { return new(SplayTree) }, (*SplayTree).check))
+}
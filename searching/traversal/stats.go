@@ -0,0 +1,53 @@
+package traversal
+
+// Stats describes the shape of a binary tree.
+// Rotation counters are filled in by the tree itself, the rest is
+// measured by ShapeOf in a single walk.
+type Stats struct {
+	Size               int
+	Height             int         // -1 for an empty tree, 0 for a single node
+	InternalPathLength int         // sum of the depths of all nodes
+	AvgDepth           float64     // InternalPathLength / Size
+	MaxDepth           int         // depth of the deepest node, equal to Height
+	Leaves             int         // nodes without children
+	BalanceFactors     map[int]int // height(left) - height(right) -> number of nodes
+	LeftRotations      int
+	RightRotations     int
+}
+
+// Returns the height of the tree, -1 if it is empty
+func Height[N Node[N]](root N) int {
+	var null N
+	if root == null {
+		return -1
+	}
+	return 1 + max(Height(root.Left()), Height(root.Right()))
+}
+
+// Measures the shape of the tree rooted at root
+func ShapeOf[N Node[N]](root N) Stats {
+	s := Stats{BalanceFactors: make(map[int]int)}
+	s.Height = shape(root, 0, &s)
+	s.MaxDepth = s.Height
+	if s.Size > 0 {
+		s.AvgDepth = float64(s.InternalPathLength) / float64(s.Size)
+	}
+	return s
+}
+
+// Accumulates the subtree at the given depth into s and returns its height
+func shape[N Node[N]](node N, depth int, s *Stats) int {
+	var null N
+	if node == null {
+		return -1
+	}
+	s.Size++
+	s.InternalPathLength += depth
+	if node.Left() == null && node.Right() == null {
+		s.Leaves++
+	}
+	lh := shape(node.Left(), depth+1, s)
+	rh := shape(node.Right(), depth+1, s)
+	s.BalanceFactors[lh-rh]++
+	return 1 + max(lh, rh)
+}
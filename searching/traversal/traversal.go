@@ -0,0 +1,165 @@
+// Node-walking helpers shared by the binary search trees in `searching`.
+// https://en.wikipedia.org/wiki/Tree_traversal
+// https://en.wikipedia.org/wiki/Threaded_binary_tree (Morris traversal)
+package traversal
+
+// Node is the view of a tree node needed to walk a tree.
+// N is the node pointer type itself, e.g. *AVLTree.Node, so that the
+// zero value of N (a nil pointer) marks an empty subtree.
+type Node[N any] interface {
+	comparable
+	Key() int
+	Left() N
+	Right() N
+}
+
+// Returns the keys in pre-order (node, left, right)
+func PreOrder[N Node[N]](root N) []int {
+	var null N
+	res := make([]int, 0)
+	stack := make([]N, 0)
+	if root != null {
+		stack = append(stack, root)
+	}
+	for len(stack) != 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		res = append(res, node.Key())
+		// push right first so that left is visited first
+		if node.Right() != null {
+			stack = append(stack, node.Right())
+		}
+		if node.Left() != null {
+			stack = append(stack, node.Left())
+		}
+	}
+	return res
+}
+
+// Returns the keys in in-order (left, node, right), i.e. in ascending order
+func InOrder[N Node[N]](root N) []int {
+	var null N
+	res := make([]int, 0)
+	stack := make([]N, 0)
+	node := root
+	for node != null || len(stack) != 0 {
+		for node != null {
+			stack = append(stack, node)
+			node = node.Left()
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		res = append(res, node.Key())
+		node = node.Right()
+	}
+	return res
+}
+
+// Returns the keys in post-order (left, right, node)
+func PostOrder[N Node[N]](root N) []int {
+	var null N
+	res := make([]int, 0)
+	stack := make([]N, 0)
+	var last N // the node visited most recently
+	node := root
+	for node != null || len(stack) != 0 {
+		for node != null {
+			stack = append(stack, node)
+			node = node.Left()
+		}
+		top := stack[len(stack)-1]
+		// go right only if the right subtree has not been visited yet
+		if top.Right() != null && top.Right() != last {
+			node = top.Right()
+		} else {
+			res = append(res, top.Key())
+			last = top
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return res
+}
+
+// Returns the keys grouped by depth, root level first,
+// each level from left to right
+func Levels[N Node[N]](root N) [][]int {
+	var null N
+	res := make([][]int, 0)
+	queue := make([]N, 0)
+	if root != null {
+		queue = append(queue, root)
+	}
+	for len(queue) != 0 {
+		// the queue holds exactly one level at this point
+		width := len(queue)
+		level := make([]int, 0, width)
+		for _, node := range queue[:width] {
+			level = append(level, node.Key())
+			for _, child := range []N{node.Left(), node.Right()} {
+				if child != null {
+					queue = append(queue, child)
+				}
+			}
+		}
+		queue = queue[width:]
+		res = append(res, level)
+	}
+	return res
+}
+
+// Returns the keys in level order as a flat slice
+func LevelOrder[N Node[N]](root N) []int {
+	res := make([]int, 0)
+	for _, level := range Levels(root) {
+		res = append(res, level...)
+	}
+	return res
+}
+
+// Returns the keys grouped by depth in zig-zag order:
+// the root level from left to right, the next one from right to left, and so on
+func ZigZag[N Node[N]](root N) [][]int {
+	res := Levels(root)
+	for depth := 1; depth < len(res); depth += 2 {
+		level := res[depth]
+		for i, j := 0, len(level)-1; i < j; i, j = i+1, j-1 {
+			level[i], level[j] = level[j], level[i]
+		}
+	}
+	return res
+}
+
+// Returns the keys in in-order with O(1) extra space.
+// Every node without a right child is temporarily linked to its in-order
+// successor; the link is removed the second time the successor is reached,
+// so the tree is left exactly as it was found. setRight rewrites the right
+// link of a node; trees pass an unexported function so that no caller
+// outside the tree can rewire it.
+func MorrisInOrder[N Node[N]](root N, setRight func(node N, right N)) []int {
+	var null N
+	res := make([]int, 0)
+	node := root
+	for node != null {
+		if node.Left() == null {
+			res = append(res, node.Key())
+			node = node.Right()
+			continue
+		}
+		// find the in-order predecessor of node
+		pred := node.Left()
+		for pred.Right() != null && pred.Right() != node {
+			pred = pred.Right()
+		}
+		if pred.Right() == null {
+			// first visit: thread the predecessor back to node
+			setRight(pred, node)
+			node = node.Left()
+		} else {
+			// second visit: the left subtree is done, remove the thread
+			setRight(pred, null)
+			res = append(res, node.Key())
+			node = node.Right()
+		}
+	}
+	return res
+}
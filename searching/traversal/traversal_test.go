@@ -0,0 +1,98 @@
+package traversal
+
+import (
+	"reflect"
+	"testing"
+)
+
+type node struct {
+	key         int
+	left, right *node
+}
+
+func (n *node) Key() int     { return n.key }
+func (n *node) Left() *node  { return n.left }
+func (n *node) Right() *node { return n.right }
+
+func setRight(n *node, r *node) { n.right = r }
+
+// Returns the tree
+//
+//	     4
+//	   /   \
+//	  2     6
+//	 / \     \
+//	1   3     7
+func sample() *node {
+	return &node{4,
+		&node{2, &node{1, nil, nil}, &node{3, nil, nil}},
+		&node{6, nil, &node{7, nil, nil}}}
+}
+
+func Test1(t *testing.T) {
+	root := sample()
+	if !reflect.DeepEqual(PreOrder(root), []int{4, 2, 1, 3, 6, 7}) {
+		t.Error("PreOrder Wrong")
+	}
+	if !reflect.DeepEqual(InOrder(root), []int{1, 2, 3, 4, 6, 7}) {
+		t.Error("InOrder Wrong")
+	}
+	if !reflect.DeepEqual(PostOrder(root), []int{1, 3, 2, 7, 6, 4}) {
+		t.Error("PostOrder Wrong")
+	}
+	if !reflect.DeepEqual(LevelOrder(root), []int{4, 2, 6, 1, 3, 7}) {
+		t.Error("LevelOrder Wrong")
+	}
+}
+
+func Test2(t *testing.T) {
+	root := sample()
+	if !reflect.DeepEqual(Levels(root), [][]int{{4}, {2, 6}, {1, 3, 7}}) {
+		t.Error("Levels Wrong")
+	}
+	if !reflect.DeepEqual(ZigZag(root), [][]int{{4}, {6, 2}, {1, 3, 7}}) {
+		t.Error("ZigZag Wrong")
+	}
+}
+
+func Test3(t *testing.T) {
+	root := sample()
+	if !reflect.DeepEqual(MorrisInOrder(root, setRight), []int{1, 2, 3, 4, 6, 7}) {
+		t.Error("MorrisInOrder Wrong")
+	}
+	// the threads must all be removed again
+	if !reflect.DeepEqual(PreOrder(root), []int{4, 2, 1, 3, 6, 7}) {
+		t.Error("MorrisInOrder modified the tree")
+	}
+}
+
+func Test4(t *testing.T) {
+	var root *node
+	if len(PreOrder(root)) != 0 || len(InOrder(root)) != 0 || len(PostOrder(root)) != 0 ||
+		len(Levels(root)) != 0 || len(ZigZag(root)) != 0 || len(MorrisInOrder(root, setRight)) != 0 {
+		t.Error("Empty Tree Wrong")
+	}
+}
+
+func Test5(t *testing.T) {
+	s := ShapeOf(sample())
+	if s.Size != 6 || s.Height != 2 || s.MaxDepth != 2 || Height(sample()) != 2 {
+		t.Error("Height Wrong")
+	}
+	// depths: 0 + 1 + 1 + 2 + 2 + 2
+	if s.InternalPathLength != 8 || s.AvgDepth != 8.0/6 {
+		t.Error("Path Length Wrong")
+	}
+	if s.Leaves != 3 {
+		t.Error("Leaves Wrong")
+	}
+	if !reflect.DeepEqual(s.BalanceFactors, map[int]int{0: 5, -1: 1}) {
+		t.Error("Balance Factors Wrong")
+	}
+
+	var empty *node
+	s = ShapeOf(empty)
+	if s.Size != 0 || s.Height != -1 || s.AvgDepth != 0 {
+		t.Error("Empty Tree Wrong")
+	}
+}
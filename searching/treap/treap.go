@@ -0,0 +1,571 @@
+// A symbol table implemented with a treap (randomized binary search tree).
+// Every node draws a random priority and the tree is kept in heap order on
+// priorities, which makes its shape that of a BST built from a random
+// insertion order whatever the actual order: O(log n) expected height.
+// https://en.wikipedia.org/wiki/Treap
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/RandomizedBST.java.html
+package treap
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"algo/utils"
+)
+
+type Node struct {
+	key         int
+	val         int
+	size        int
+	priority    uint64 // a parent's priority is never below its children's
+	left, right *Node
+}
+
+// Returns the key stored in the node
+func (n *Node) Key() int {
+	return n.key
+}
+
+// Returns the value stored in the node
+func (n *Node) Val() int {
+	return n.val
+}
+
+// Returns the left child, nil if there is none
+func (n *Node) Left() *Node {
+	return n.left
+}
+
+// Returns the right child, nil if there is none
+func (n *Node) Right() *Node {
+	return n.right
+}
+
+// The struct represents an ordered symbol table of int key-value pairs.
+// The zero value is an empty treap seeded with 0.
+type Treap struct {
+	root *Node
+	rng  *rand.Rand
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+	// the identity of the ordering, nil for ascending order
+	order *ordering
+}
+
+// Tells orderings apart for Merge. Comparators cannot be compared, since
+// two closures of one function literal look the same and may still order
+// keys differently, so every NewWithComparator call makes a new ordering
+// and Split hands it on.
+type ordering struct {
+	_ byte
+}
+
+// Returns an empty treap whose priorities are drawn from a generator
+// seeded with seed, so the same operations always build the same tree
+func New(seed uint64) *Treap {
+	return &Treap{rng: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// have such a helper function to avoid visiting nil node
+func (t *Treap) size(node *Node) int {
+	if node == nil {
+		return 0
+	} else {
+		return node.size
+	}
+}
+
+// Returns an empty treap seeded with 0, ordered by compare
+func NewWithComparator(compare utils.Comparator) *Treap {
+	t := New(0)
+	t.comparator = compare
+	if compare != nil {
+		t.order = new(ordering)
+	}
+	return t
+}
+
+// Draws the next random number, seeding the generator with 0 on first use
+func (t *Treap) random() uint64 {
+	if t.rng == nil {
+		t.rng = rand.New(rand.NewPCG(0, 0))
+	}
+	return t.rng.Uint64()
+}
+
+// Allocates a node with a fresh random priority
+func (t *Treap) newNode(key int, val int) *Node {
+	return &Node{key: key, val: val, size: 1, priority: t.random()}
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *Treap) Size() int {
+	return t.size(t.root)
+}
+
+// Splits the subtree into the keys less than key and the keys greater than or equal to key
+func (t *Treap) split(node *Node, key int) (*Node, *Node) {
+	if node == nil {
+		return nil, nil
+	}
+	if t.comparator.Compare(node.key, key) < 0 {
+		left, right := t.split(node.right, key)
+		node.right = left
+		node.size = 1 + t.size(node.left) + t.size(node.right)
+		return node, right
+	}
+	left, right := t.split(node.left, key)
+	node.left = right
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+	return left, node
+}
+
+// Joins two subtrees where every key of left is less than every key of right
+func (t *Treap) merge(left *Node, right *Node) *Node {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.priority >= right.priority {
+		left.right = t.merge(left.right, right)
+		left.size = 1 + t.size(left.left) + t.size(left.right)
+		return left
+	}
+	right.left = t.merge(left, right.left)
+	right.size = 1 + t.size(right.left) + t.size(right.right)
+	return right
+}
+
+// Returns the node by key
+func (t *Treap) get(n *Node, key int) *Node {
+	if n == nil {
+		return nil
+	} else {
+		cmp := t.comparator.Compare(key, n.key)
+		if cmp == 0 {
+			return n
+		} else if cmp < 0 {
+			return t.get(n.left, key)
+		} else {
+			return t.get(n.right, key)
+		}
+	}
+}
+
+// Get value by key, return 0 if not exist
+func (t *Treap) Get(key int) int {
+	n := t.get(t.root, key)
+	if n != nil {
+		return n.val
+	} else {
+		return 0
+	}
+}
+
+// Return true if the key exists in the symbol table
+func (t *Treap) Contains(key int) bool {
+	n := t.get(t.root, key)
+	return n != nil
+}
+
+// Inserts the node below the first node on the search path with a lower priority
+func (t *Treap) put(node *Node, n *Node) *Node {
+	if node == nil {
+		return n
+	}
+	if n.priority > node.priority {
+		n.left, n.right = t.split(node, n.key)
+		n.size = 1 + t.size(n.left) + t.size(n.right)
+		return n
+	}
+	if t.comparator.Compare(n.key, node.key) < 0 {
+		node.left = t.put(node.left, n)
+	} else {
+		node.right = t.put(node.right, n)
+	}
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+	return node
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *Treap) Put(key int, val int) {
+	if n := t.get(t.root, key); n != nil {
+		n.val = val
+		return
+	}
+	t.root = t.put(t.root, t.newNode(key, val))
+}
+
+func (t *Treap) delete(node *Node, key int) *Node {
+	if node == nil {
+		return nil
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp == 0 {
+		return t.merge(node.left, node.right)
+	} else if cmp < 0 {
+		node.left = t.delete(node.left, key)
+	} else {
+		node.right = t.delete(node.right, key)
+	}
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+	return node
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *Treap) Delete(key int) {
+	t.root = t.delete(t.root, key)
+}
+
+func (t *Treap) findMin(node *Node) *Node {
+	if node == nil {
+		return node
+	}
+
+	if node.left != nil {
+		return t.findMin(node.left)
+	}
+	return node
+}
+
+func (t *Treap) findMax(node *Node) *Node {
+	if node == nil {
+		return node
+	}
+
+	if node.right != nil {
+		return t.findMax(node.right)
+	}
+	return node
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (t *Treap) DeleteMin() {
+	if t.Size() == 0 {
+		return
+	}
+	t.Delete(t.findMin(t.root).key)
+}
+
+// Removes the largest key and associated value from the symbol table
+func (t *Treap) DeleteMax() {
+	if t.Size() == 0 {
+		return
+	}
+	t.Delete(t.findMax(t.root).key)
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *Treap) Remove(key int) (val int, ok bool) {
+	n := t.get(t.root, key)
+	if n == nil {
+		return 0, false
+	}
+	t.Delete(key)
+	return n.val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the treap is empty
+func (t *Treap) PopMin() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	minNode := t.findMin(t.root)
+	t.Delete(minNode.key)
+	return minNode.key, minNode.val, true
+}
+
+// Removes the largest key and returns it with its value; false if the treap is empty
+func (t *Treap) PopMax() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	maxNode := t.findMax(t.root)
+	t.Delete(maxNode.key)
+	return maxNode.key, maxNode.val, true
+}
+
+// Removes every key in [lo, hi] and returns how many there were. The range
+// is split off and the rest merged back, O(log n) expected time however
+// many keys go.
+func (t *Treap) DeleteRange(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	left, rest := t.split(t.root, lo)
+	middle, right := t.split(rest, hi)
+	removed := t.size(middle)
+	// split leaves hi itself on the right
+	if minNode := t.findMin(right); minNode != nil && t.comparator.Compare(minNode.key, hi) == 0 {
+		right = t.delete(right, hi)
+		removed++
+	}
+	t.root = t.merge(left, right)
+	return removed
+}
+
+func (t *Treap) Min() (key int, val int) {
+	minNode := t.findMin(t.root)
+	return minNode.key, minNode.val
+}
+
+func (t *Treap) Max() (key int, val int) {
+	maxNode := t.findMax(t.root)
+	return maxNode.key, maxNode.val
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (t *Treap) floor(node *Node, key int) *Node {
+	if node == nil {
+		return node
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp == 0 {
+		return node
+	} else if cmp > 0 {
+		r := t.floor(node.right, key)
+		if r != nil {
+			return r
+		} else {
+			return node
+		}
+	} else {
+		return t.floor(node.left, key)
+	}
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (t *Treap) Floor(key int) *Node {
+	return t.floor(t.root, key)
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (t *Treap) ceiling(node *Node, key int) *Node {
+	if node == nil {
+		return node
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp == 0 {
+		return node
+	} else if cmp < 0 {
+		r := t.ceiling(node.left, key)
+		if r != nil {
+			return r
+		} else {
+			return node
+		}
+	} else {
+		return t.ceiling(node.right, key)
+	}
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (t *Treap) Ceiling(key int) *Node {
+	return t.ceiling(t.root, key)
+}
+
+func (t *Treap) selectHelper(node *Node, k int) *Node {
+	if node == nil {
+		return node
+	}
+	if t.size(node.left) == k { // say k = 0, should return the smallest
+		return node
+	} else if t.size(node.left) < k {
+		return t.selectHelper(node.right, k-1-t.size(node.left))
+	} else {
+		return t.selectHelper(node.left, k)
+	}
+}
+
+// Return the node in the symbol table whose rank is k
+func (t *Treap) Select(k int) *Node {
+	return t.selectHelper(t.root, k)
+}
+
+func (t *Treap) rank(node *Node, key int) int {
+	if node == nil {
+		return 0
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp > 0 {
+		return t.size(node.left) + 1 + t.rank(node.right, key)
+	} else if cmp == 0 {
+		return t.size(node.left)
+	} else {
+		return t.rank(node.left, key)
+	}
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (t *Treap) Rank(key int) int {
+	return t.rank(t.root, key)
+}
+
+func (t *Treap) keys(node *Node, res *[]int) {
+	if node == nil {
+		return
+	} else {
+		t.keys(node.left, res)
+		*res = append(*res, node.key)
+		t.keys(node.right, res)
+	}
+}
+
+// Returns all keys in the symbol table as an Iterable
+func (t *Treap) Keys() []int {
+	var res []int
+	t.keys(t.root, &res)
+	return res
+}
+
+func (t *Treap) rangekeys(node *Node, lo int, hi int, res *[]int) {
+	if node == nil {
+		return
+	}
+	if t.comparator.Compare(lo, node.key) > 0 {
+		t.rangekeys(node.right, lo, hi, res)
+	} else if t.comparator.Compare(hi, node.key) < 0 {
+		t.rangekeys(node.left, lo, hi, res)
+	} else {
+		t.rangekeys(node.left, lo, hi, res)
+		*res = append(*res, node.key)
+		t.rangekeys(node.right, lo, hi, res)
+	}
+}
+
+// Returns all keys in the symbol table in the given range.
+func (t *Treap) RangeKeys(lo int, hi int) []int {
+	var res []int
+	t.rangekeys(t.root, lo, hi, &res)
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (t *Treap) RangeSize(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	if t.Contains(hi) {
+		return t.Rank(hi) - t.Rank(lo) + 1
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
+// Moves the keys greater than or equal to key into a new treap, which is
+// returned with the same comparator; t keeps the smaller keys.
+// O(log n) expected time.
+func (t *Treap) Split(key int) *Treap {
+	other := New(t.random())
+	other.comparator, other.order = t.comparator, t.order
+	t.root, other.root = t.split(t.root, key)
+	return other
+}
+
+// Moves every key of other into t, leaving other empty. Both treaps must
+// share one ordering: either both use ascending order, or one was split off
+// or cloned from the other, possibly through other splits and clones. Treaps from separate
+// NewWithComparator calls never merge, even with the same comparator.
+// Every key of t must come before every key of other. O(log n) expected time.
+func (t *Treap) Merge(other *Treap) {
+	if t.order != other.order {
+		panic("treap: Merge needs both treaps to share one ordering")
+	}
+	if t.root != nil && other.root != nil && t.comparator.Compare(t.findMax(t.root).key, t.findMin(other.root).key) >= 0 {
+		panic("treap: Merge needs every key of t below every key of other")
+	}
+	t.root = t.merge(t.root, other.root)
+	other.root = nil
+}
+
+// Returns a deep copy of the treap with the same shape, sizes and
+// priorities. The copy keeps the ordering, so it merges with t and its
+// splits, and draws later priorities from a generator seeded by t's. O(n).
+func (t *Treap) Clone() *Treap {
+	c := New(t.random())
+	c.root, c.comparator, c.order = t.clone(t.root), t.comparator, t.order
+	return c
+}
+
+func (t *Treap) clone(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.left, copied.right = t.clone(node.left), t.clone(node.right)
+	return &copied
+}
+
+// Pushes node and the chain of its left children onto the stack
+func pushLeft(stack []*Node, node *Node) []*Node {
+	for ; node != nil; node = node.left {
+		stack = append(stack, node)
+	}
+	return stack
+}
+
+// Reports whether the two trees hold the same key-value pairs in the same
+// order, whatever their shapes. Both are walked in order side by side, O(n).
+func (t *Treap) Equal(other *Treap) bool {
+	if t.Size() != other.Size() {
+		return false
+	}
+	s1, s2 := pushLeft(nil, t.root), pushLeft(nil, other.root)
+	for len(s1) != 0 {
+		a, b := s1[len(s1)-1], s2[len(s2)-1]
+		if a.key != b.key || a.val != b.val {
+			return false
+		}
+		s1 = pushLeft(s1[:len(s1)-1], a.right)
+		s2 = pushLeft(s2[:len(s2)-1], b.right)
+	}
+	return true
+}
+
+// Reports whether the two trees are identical node for node: the same keys
+// and values at the same places. Meant for test assertions, e.g. that a
+// Clone reproduced the tree exactly.
+func (t *Treap) EqualShape(other *Treap) bool {
+	return t.equalShape(t.root, other.root)
+}
+
+func (t *Treap) equalShape(a *Node, b *Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.key == b.key && a.val == b.val && t.equalShape(a.left, b.left) && t.equalShape(a.right, b.right)
+}
+
+// Removes every key; the ordering and the generator stay
+func (t *Treap) Clear() {
+	t.root = nil
+}
+
+// Checks the invariants of the treap: symmetric order on keys,
+// heap order on priorities and subtree sizes
+func (t *Treap) check() error {
+	return t.checkNode(t.root, nil, nil)
+}
+
+// lo and hi bound the keys allowed in the subtree, nil meaning unbounded
+func (t *Treap) checkNode(node *Node, lo *int, hi *int) error {
+	if node == nil {
+		return nil
+	}
+	if (lo != nil && t.comparator.Compare(node.key, *lo) <= 0) || (hi != nil && t.comparator.Compare(node.key, *hi) >= 0) {
+		return fmt.Errorf("key %d is out of order", node.key)
+	}
+	if node.size != 1+t.size(node.left)+t.size(node.right) {
+		return fmt.Errorf("node %d has size %d", node.key, node.size)
+	}
+	for _, child := range []*Node{node.left, node.right} {
+		if child != nil && child.priority > node.priority {
+			return fmt.Errorf("node %d has a child with a higher priority", node.key)
+		}
+	}
+	if err := t.checkNode(node.left, lo, &node.key); err != nil {
+		return err
+	}
+	return t.checkNode(node.right, &node.key, hi)
+}
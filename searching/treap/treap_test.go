@@ -0,0 +1,154 @@
+package treap
+
+import (
+	"algo/searching/conformance"
+	"algo/searching/traversal"
+	"cmp"
+	"reflect"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	var tree *Treap = new(Treap)
+	if tree.Size() != 0 {
+		t.Fail()
+	}
+	tree.Put(1, 3)
+	tree.Put(1, 4)
+	tree.Put(2, 3)
+	tree.Put(4, 5)
+	tree.Put(10, 1)
+	if tree.Size() != 4 || !tree.Contains(1) || tree.Get(1) != 4 || tree.Get(0) != 0 {
+		t.Error("Put Wrong")
+	}
+	if n := tree.Floor(3); n == nil || n.Key() != 2 {
+		t.Error("Floor Wrong")
+	}
+	if n := tree.Ceiling(3); n == nil || n.Key() != 4 {
+		t.Error("Ceiling Wrong")
+	}
+	if n := tree.Select(2); n == nil || n.Key() != 4 || tree.Rank(4) != 2 || tree.Rank(5) != 3 {
+		t.Error("Select/Rank Wrong")
+	}
+	tree.DeleteMin()
+	tree.DeleteMax()
+	tree.Delete(2)
+	minkey, minval := tree.Min()
+	if tree.Size() != 1 || minkey != 4 || minval != 5 {
+		t.Error("Delete Wrong")
+	}
+}
+
+// keys inserted in ascending order must not degenerate
+func Test2(t *testing.T) {
+	tree := New(42)
+	for i := 0; i < 1<<12; i++ {
+		tree.Put(i, i)
+	}
+	if h := traversal.Height(tree.root); h > 4*12 {
+		t.Errorf("Height %d Too Large", h)
+	}
+}
+
+// the same seed and operations give the same tree
+func Test3(t *testing.T) {
+	build := func(seed uint64) *Treap {
+		tree := New(seed)
+		for i := 0; i < 100; i++ {
+			tree.Put((i*37)%101, i)
+		}
+		return tree
+	}
+	if !reflect.DeepEqual(traversal.PreOrder(build(7).root), traversal.PreOrder(build(7).root)) {
+		t.Error("Seeding Wrong")
+	}
+	if reflect.DeepEqual(traversal.PreOrder(build(7).root), traversal.PreOrder(build(8).root)) {
+		t.Error("Seed Ignored")
+	}
+}
+
+func Test4(t *testing.T) {
+	tree := New(1)
+	for i := 0; i < 10; i++ {
+		tree.Put(i, i*10)
+	}
+	right := tree.Split(6)
+	if !reflect.DeepEqual(tree.Keys(), []int{0, 1, 2, 3, 4, 5}) || !reflect.DeepEqual(right.Keys(), []int{6, 7, 8, 9}) {
+		t.Error("Split Wrong")
+	}
+	if tree.check() != nil || right.check() != nil {
+		t.Error("Split Broke Invariants")
+	}
+	tree.Merge(right)
+	if tree.Size() != 10 || right.Size() != 0 || tree.Get(7) != 70 || tree.check() != nil {
+		t.Error("Merge Wrong")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Overlapping Merge Accepted")
+		}
+	}()
+	other := New(2)
+	other.Put(5, 5)
+	tree.Merge(other)
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapt[*Node](func() *Treap { return New(1) }, (*Treap).check))
+}
+
+func TestReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Node](func() *Treap { return NewWithComparator(conformance.Descending) }, (*Treap).check))
+
+	// the conformance suite never calls Split or Merge, so the comparator
+	// carried over by Split is checked here
+	tree := NewWithComparator(conformance.Descending)
+	for i := 1; i <= 6; i++ {
+		tree.Put(i, i)
+	}
+	other := tree.Split(3)
+	other.Put(0, 0)
+	other.Put(10, 10)
+	if !reflect.DeepEqual(other.Keys(), []int{10, 3, 2, 1, 0}) || other.check() != nil {
+		t.Error("Split Wrong", other.Keys())
+	}
+	other.Delete(10)
+	if !reflect.DeepEqual(tree.Keys(), []int{6, 5, 4}) {
+		t.Error("Split Wrong", tree.Keys())
+	}
+	tree.Merge(other)
+	if !reflect.DeepEqual(tree.Keys(), []int{6, 5, 4, 3, 2, 1, 0}) || other.Size() != 0 || tree.check() != nil {
+		t.Error("Merge Wrong", tree.Keys())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Mixed Orders Accepted")
+		}
+	}()
+	tree.Merge(New(1))
+}
+
+// closures of one function literal share their code, yet order keys
+// differently, so Merge must not take them for one ordering
+func TestMergeClosures(t *testing.T) {
+	offset := func(k int) func(a int, b int) int {
+		return func(a int, b int) int {
+			return cmp.Compare((a+k)%10, (b+k)%10)
+		}
+	}
+	tree, other := NewWithComparator(offset(0)), NewWithComparator(offset(5))
+	tree.Put(1, 1)
+	other.Put(9, 9)
+	defer func() {
+		if recover() == nil {
+			t.Error("Mixed Orders Accepted", tree.Keys())
+		}
+	}()
+	tree.Merge(other)
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Node](func() *Treap { return New(1) }, (*Treap).check))
+}
@@ -0,0 +1,378 @@
+// Symbol tables with string keys and int values that answer prefix
+// queries: TrieST, an R-way trie, and TST, a ternary search trie.
+// Keys are sequences of bytes and come out in byte order.
+// https://algs4.cs.princeton.edu/52trie/
+package trie
+
+import (
+	"fmt"
+)
+
+// The alphabet size of TrieST, one link per byte value
+const R = 256
+
+type trieNode struct {
+	val  int
+	ok   bool // whether a key ends at this node
+	next [R]*trieNode
+}
+
+// An R-way trie: a node per key prefix, each with a link for every byte.
+// Search and insert cost one step per byte of the key whatever the number
+// of keys, at R links of memory per node.
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/TrieST.java.html
+type TrieST struct {
+	root *trieNode
+	n    int
+}
+
+func NewTrieST() *TrieST {
+	return new(TrieST)
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *TrieST) Size() int {
+	return t.n
+}
+
+// Returns the node of the prefix, nil if no key starts with it
+func (t *TrieST) get(node *trieNode, key string, d int) *trieNode {
+	for ; node != nil && d < len(key); d++ {
+		node = node.next[key[d]]
+	}
+	return node
+}
+
+// Get value by key, return 0 if not exist
+func (t *TrieST) Get(key string) int {
+	node := t.get(t.root, key, 0)
+	if node == nil {
+		return 0
+	}
+	return node.val
+}
+
+// Return true if the key exists in the symbol table
+func (t *TrieST) Contains(key string) bool {
+	node := t.get(t.root, key, 0)
+	return node != nil && node.ok
+}
+
+func (t *TrieST) put(node *trieNode, key string, val int, d int) *trieNode {
+	if node == nil {
+		node = new(trieNode)
+	}
+	if d == len(key) {
+		if !node.ok {
+			t.n++
+		}
+		node.val, node.ok = val, true
+		return node
+	}
+	node.next[key[d]] = t.put(node.next[key[d]], key, val, d+1)
+	return node
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *TrieST) Put(key string, val int) {
+	t.root = t.put(t.root, key, val, 0)
+}
+
+// Removes the key below node and returns nil if node is left with neither
+// a key nor links
+func (t *TrieST) delete(node *trieNode, key string, d int) *trieNode {
+	if node == nil {
+		return nil
+	}
+	if d == len(key) {
+		if node.ok {
+			t.n--
+		}
+		node.val, node.ok = 0, false
+	} else {
+		node.next[key[d]] = t.delete(node.next[key[d]], key, d+1)
+	}
+	if node.ok {
+		return node
+	}
+	for _, next := range node.next {
+		if next != nil {
+			return node
+		}
+	}
+	return nil
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *TrieST) Delete(key string) {
+	t.root = t.delete(t.root, key, 0)
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *TrieST) Remove(key string) (val int, ok bool) {
+	if !t.Contains(key) {
+		return 0, false
+	}
+	val = t.Get(key)
+	t.Delete(key)
+	return val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the trie is empty
+func (t *TrieST) PopMin() (key string, val int, ok bool) {
+	if t.n == 0 {
+		return "", 0, false
+	}
+	// a key sorts before the keys it is a prefix of
+	var path []byte
+	for node := t.root; !node.ok; {
+		c := 0
+		for node.next[c] == nil {
+			c++
+		}
+		path = append(path, byte(c))
+		node = node.next[c]
+	}
+	key = string(path)
+	val, _ = t.Remove(key)
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the trie is empty
+func (t *TrieST) PopMax() (key string, val int, ok bool) {
+	if t.n == 0 {
+		return "", 0, false
+	}
+	var path []byte
+	for node := t.root; ; {
+		c := R - 1
+		for c >= 0 && node.next[c] == nil {
+			c--
+		}
+		if c < 0 {
+			break
+		}
+		path = append(path, byte(c))
+		node = node.next[c]
+	}
+	key = string(path)
+	val, _ = t.Remove(key)
+	return key, val, true
+}
+
+// Returns the number of keys below node
+func (t *TrieST) count(node *trieNode) int {
+	if node == nil {
+		return 0
+	}
+	res := 0
+	if node.ok {
+		res++
+	}
+	for _, next := range node.next {
+		res += t.count(next)
+	}
+	return res
+}
+
+// Removes the keys in [lo, hi] below node, which is at depth d. checkLo is
+// set while the path to node is a prefix of lo, so that keys below it may be
+// less than lo, and checkHi likewise for hi. Returns nil if node is left
+// with neither a key nor links.
+func (t *TrieST) deleteRange(node *trieNode, lo string, hi string, d int, checkLo bool, checkHi bool) *trieNode {
+	if node == nil {
+		return nil
+	}
+	if !checkLo && !checkHi {
+		t.n -= t.count(node)
+		return nil
+	}
+	// the key ending here is a prefix of hi, and of lo it is lo itself or less
+	if node.ok && (!checkLo || d == len(lo)) {
+		node.val, node.ok = 0, false
+		t.n--
+	}
+	empty := !node.ok
+	for c, next := range node.next {
+		if next == nil || (checkLo && d < len(lo) && byte(c) < lo[d]) || (checkHi && (d == len(hi) || byte(c) > hi[d])) {
+			empty = empty && next == nil
+			continue
+		}
+		node.next[c] = t.deleteRange(next, lo, hi, d+1, checkLo && d < len(lo) && byte(c) == lo[d], checkHi && byte(c) == hi[d])
+		empty = empty && node.next[c] == nil
+	}
+	if empty {
+		return nil
+	}
+	return node
+}
+
+// Removes every key in [lo, hi] and returns how many there were. Only the
+// paths of lo and hi are searched; every subtree between them is dropped
+// whole, at a cost of one step per node removed.
+func (t *TrieST) DeleteRange(lo string, hi string) int {
+	if lo > hi {
+		return 0
+	}
+	n := t.n
+	t.root = t.deleteRange(t.root, lo, hi, 0, true, true)
+	return n - t.n
+}
+
+// Appends every key below node to res, prefix being the path to node
+func (t *TrieST) collect(node *trieNode, prefix []byte, res *[]string) {
+	if node == nil {
+		return
+	}
+	if node.ok {
+		*res = append(*res, string(prefix))
+	}
+	for c, next := range node.next {
+		if next != nil {
+			t.collect(next, append(prefix, byte(c)), res)
+		}
+	}
+}
+
+// Returns all keys in the symbol table in byte order
+func (t *TrieST) Keys() []string {
+	return t.KeysWithPrefix("")
+}
+
+// Returns all keys that start with prefix, in byte order
+func (t *TrieST) KeysWithPrefix(prefix string) []string {
+	var res []string
+	t.collect(t.get(t.root, prefix, 0), []byte(prefix), &res)
+	return res
+}
+
+func (t *TrieST) match(node *trieNode, prefix []byte, pattern string, res *[]string) {
+	if node == nil {
+		return
+	}
+	d := len(prefix)
+	if d == len(pattern) {
+		if node.ok {
+			*res = append(*res, string(prefix))
+		}
+		return
+	}
+	if c := pattern[d]; c != '.' {
+		t.match(node.next[c], append(prefix, c), pattern, res)
+		return
+	}
+	for c, next := range node.next {
+		t.match(next, append(prefix, byte(c)), pattern, res)
+	}
+}
+
+// Returns all keys of the same length as pattern that match it,
+// where '.' matches any byte, in byte order
+func (t *TrieST) KeysThatMatch(pattern string) []string {
+	var res []string
+	t.match(t.root, make([]byte, 0, len(pattern)), pattern, &res)
+	return res
+}
+
+// Returns the longest key that is a prefix of query, and false if there is none
+func (t *TrieST) LongestPrefixOf(query string) (string, bool) {
+	length, found := 0, false
+	node := t.root
+	for d := 0; node != nil; d++ {
+		if node.ok {
+			length, found = d, true
+		}
+		if d == len(query) {
+			break
+		}
+		node = node.next[query[d]]
+	}
+	return query[:length], found
+}
+
+// Returns a deep copy of the trie. O(R n) for n nodes.
+func (t *TrieST) Clone() *TrieST {
+	return &TrieST{root: t.clone(t.root), n: t.n}
+}
+
+func (t *TrieST) clone(node *trieNode) *trieNode {
+	if node == nil {
+		return nil
+	}
+	copied := &trieNode{val: node.val, ok: node.ok}
+	for c, next := range node.next {
+		copied.next[c] = t.clone(next)
+	}
+	return copied
+}
+
+// Reports whether the two tries hold the same key-value pairs. A trie has
+// one shape for a set of keys, so both are compared node for node.
+func (t *TrieST) Equal(other *TrieST) bool {
+	return t.n == other.n && t.equal(t.root, other.root)
+}
+
+func (t *TrieST) equal(a *trieNode, b *trieNode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.ok != b.ok || a.val != b.val {
+		return false
+	}
+	for c := range a.next {
+		if !t.equal(a.next[c], b.next[c]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Same as Equal: the keys decide the shape
+func (t *TrieST) EqualShape(other *TrieST) bool {
+	return t.Equal(other)
+}
+
+// Removes every key
+func (t *TrieST) Clear() {
+	t.root = nil
+	t.n = 0
+}
+
+// Checks the invariants of the trie: the key count and that every leaf holds a key
+func (t *TrieST) check() error {
+	count, err := t.checkNode(t.root)
+	if err != nil {
+		return err
+	}
+	if count != t.n {
+		return fmt.Errorf("trie holds %d keys, size is %d", count, t.n)
+	}
+	return nil
+}
+
+// Returns the number of keys below node
+func (t *TrieST) checkNode(node *trieNode) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+	count, leaf := 0, true
+	if node.ok {
+		count++
+	} else if node.val != 0 {
+		return 0, fmt.Errorf("node without a key holds value %d", node.val)
+	}
+	for _, next := range node.next {
+		if next != nil {
+			leaf = false
+			c, err := t.checkNode(next)
+			if err != nil {
+				return 0, err
+			}
+			count += c
+		}
+	}
+	if leaf && !node.ok {
+		return 0, fmt.Errorf("leaf without a key")
+	}
+	return count, nil
+}
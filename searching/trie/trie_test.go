@@ -0,0 +1,321 @@
+package trie
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// The API shared by TrieST and TST
+type stringST interface {
+	Size() int
+	Get(key string) int
+	Contains(key string) bool
+	Put(key string, val int)
+	Delete(key string)
+	PopMin() (key string, val int, ok bool)
+	PopMax() (key string, val int, ok bool)
+	DeleteRange(lo string, hi string) int
+	Keys() []string
+	KeysWithPrefix(prefix string) []string
+	KeysThatMatch(pattern string) []string
+	LongestPrefixOf(query string) (string, bool)
+	check() error
+}
+
+var backends = []struct {
+	name string
+	new  func() stringST
+}{
+	{"TrieST", func() stringST { return NewTrieST() }},
+	{"TST", func() stringST { return NewTST() }},
+}
+
+func Test1(t *testing.T) {
+	for _, backend := range backends {
+		st := backend.new()
+		for i, key := range strings.Fields("she sells sea shells by the sea shore") {
+			st.Put(key, i)
+		}
+		if st.Size() != 7 || st.Get("sea") != 6 || st.Get("se") != 0 || st.Contains("se") || !st.Contains("shore") {
+			t.Error(backend.name + " Put Wrong")
+		}
+		if !reflect.DeepEqual(st.Keys(), []string{"by", "sea", "sells", "she", "shells", "shore", "the"}) {
+			t.Error(backend.name+" Keys Wrong", st.Keys())
+		}
+		if !reflect.DeepEqual(st.KeysWithPrefix("sh"), []string{"she", "shells", "shore"}) || st.KeysWithPrefix("x") != nil {
+			t.Error(backend.name+" KeysWithPrefix Wrong", st.KeysWithPrefix("sh"))
+		}
+		if !reflect.DeepEqual(st.KeysThatMatch(".he"), []string{"she", "the"}) || !reflect.DeepEqual(st.KeysThatMatch("s.."), []string{"sea", "she"}) {
+			t.Error(backend.name+" KeysThatMatch Wrong", st.KeysThatMatch(".he"))
+		}
+		if p, ok := st.LongestPrefixOf("shellsort"); !ok || p != "shells" {
+			t.Error(backend.name + " LongestPrefixOf Wrong " + p)
+		}
+		if p, ok := st.LongestPrefixOf("quicksort"); ok || p != "" {
+			t.Error(backend.name + " LongestPrefixOf Wrong " + p)
+		}
+		st.Delete("shells")
+		st.Delete("shellfish")
+		if st.Size() != 6 || st.Contains("shells") || !st.Contains("she") || st.check() != nil {
+			t.Error(backend.name + " Delete Wrong")
+		}
+		if p, _ := st.LongestPrefixOf("shellsort"); p != "she" {
+			t.Error(backend.name + " LongestPrefixOf After Delete Wrong " + p)
+		}
+	}
+}
+
+// the empty key is a key like any other
+func Test2(t *testing.T) {
+	for _, backend := range backends {
+		st := backend.new()
+		st.Put("a", 1)
+		if _, ok := st.LongestPrefixOf("abc"); !ok || st.Contains("") {
+			t.Error(backend.name + " Empty Key Wrong")
+		}
+		st.Put("", 5)
+		if p, ok := st.LongestPrefixOf("xyz"); !ok || p != "" || st.Get("") != 5 || st.Size() != 2 {
+			t.Error(backend.name + " Empty Key Wrong")
+		}
+		if !reflect.DeepEqual(st.Keys(), []string{"", "a"}) || !reflect.DeepEqual(st.KeysThatMatch(""), []string{""}) {
+			t.Error(backend.name+" Empty Key Wrong", st.Keys())
+		}
+		st.Delete("")
+		if st.Contains("") || st.Size() != 1 || st.check() != nil {
+			t.Error(backend.name + " Empty Key Delete Wrong")
+		}
+	}
+}
+
+// Applies operations decoded from ops to st and to a map, failing as soon
+// as they disagree. Keys are short strings over a small alphabet, so
+// prefixes are shared all the time.
+func apply(t *testing.T, st stringST, ops []byte) {
+	t.Helper()
+	ref := make(map[string]int)
+	key := func(b byte) string {
+		s := make([]byte, b%4)
+		for j := range s {
+			s[j] = "ab"[b>>(2+j)&1]
+		}
+		return string(s)
+	}
+	for i := 0; i+2 < len(ops); i += 3 {
+		k, v := key(ops[i+1]), int(ops[i+2])
+		switch ops[i] % 8 {
+		case 0, 1:
+			st.Put(k, v)
+			ref[k] = v
+		case 2:
+			st.Delete(k)
+			delete(ref, k)
+		case 3:
+			var want []string
+			for r := range ref {
+				if strings.HasPrefix(r, k) {
+					want = append(want, r)
+				}
+			}
+			sort.Strings(want)
+			if got := st.KeysWithPrefix(k); !reflect.DeepEqual(got, want) {
+				t.Fatalf("op %d: KeysWithPrefix(%q) = %q, want %q", i/3, k, got, want)
+			}
+		case 4:
+			pattern := []byte(k)
+			for j := range pattern {
+				if v>>j&1 == 1 {
+					pattern[j] = '.'
+				}
+			}
+			var want []string
+			for r := range ref {
+				if len(r) == len(pattern) && matches(r, string(pattern)) {
+					want = append(want, r)
+				}
+			}
+			sort.Strings(want)
+			if got := st.KeysThatMatch(string(pattern)); !reflect.DeepEqual(got, want) {
+				t.Fatalf("op %d: KeysThatMatch(%q) = %q, want %q", i/3, pattern, got, want)
+			}
+		case 5:
+			query := k + key(byte(v))
+			want, wantOk := "", false
+			for r := range ref {
+				if strings.HasPrefix(query, r) && (!wantOk || len(r) > len(want)) {
+					want, wantOk = r, true
+				}
+			}
+			if got, ok := st.LongestPrefixOf(query); got != want || ok != wantOk {
+				t.Fatalf("op %d: LongestPrefixOf(%q) = %q %v, want %q %v", i/3, query, got, ok, want, wantOk)
+			}
+		case 6:
+			lo, hi := min(k, key(byte(v))), max(k, key(byte(v)))
+			removed := 0
+			for r := range ref {
+				if lo <= r && r <= hi {
+					delete(ref, r)
+					removed++
+				}
+			}
+			if got := st.DeleteRange(lo, hi); got != removed {
+				t.Fatalf("op %d: DeleteRange(%q, %q) = %d, want %d", i/3, lo, hi, got, removed)
+			}
+		case 7:
+			var keys []string
+			for r := range ref {
+				keys = append(keys, r)
+			}
+			sort.Strings(keys)
+			want, pop := "", st.PopMin
+			if len(keys) > 0 {
+				want = keys[0]
+			}
+			if v&1 == 1 {
+				pop = st.PopMax
+				if len(keys) > 0 {
+					want = keys[len(keys)-1]
+				}
+			}
+			if got, val, ok := pop(); got != want || val != ref[want] || ok != (len(keys) > 0) {
+				t.Fatalf("op %d: pop = %q %d %v, want %q %d", i/3, got, val, ok, want, ref[want])
+			}
+			delete(ref, want)
+		}
+		if st.Get(k) != ref[k] || st.Size() != len(ref) {
+			t.Fatalf("op %d: Get(%q) = %d, want %d", i/3, k, st.Get(k), ref[k])
+		}
+		if err := st.check(); err != nil {
+			t.Fatalf("op %d: %v", i/3, err)
+		}
+	}
+}
+
+// Returns true if key matches pattern of the same length, '.' matching any byte
+func matches(key string, pattern string) bool {
+	for i := range pattern {
+		if pattern[i] != '.' && pattern[i] != key[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func Test3(t *testing.T) {
+	for _, backend := range backends {
+		for seed := uint64(0); seed < 50; seed++ {
+			r := rand.New(rand.NewPCG(seed, seed))
+			ops := make([]byte, 3*500)
+			for i := range ops {
+				ops[i] = byte(r.UintN(256))
+			}
+			apply(t, backend.new(), ops)
+		}
+	}
+}
+
+func Test4(t *testing.T) {
+	for _, backend := range backends {
+		st := backend.new()
+		if _, _, ok := st.PopMin(); ok || st.DeleteRange("", "z") != 0 {
+			t.Error(backend.name + " Empty Pop Wrong")
+		}
+		for i, key := range strings.Fields("she sells sea shells by the sea shore") {
+			st.Put(key, i)
+		}
+		st.Put("", 7)
+		if key, val, ok := st.PopMin(); !ok || key != "" || val != 7 {
+			t.Error(backend.name+" PopMin Wrong", key, val)
+		}
+		if key, val, ok := st.PopMax(); !ok || key != "the" || val != 5 {
+			t.Error(backend.name+" PopMax Wrong", key, val)
+		}
+		if key, _, _ := st.PopMin(); key != "by" || st.Size() != 5 {
+			t.Error(backend.name+" PopMin Wrong", key)
+		}
+		if st.DeleteRange("sells", "shells") != 3 || !reflect.DeepEqual(st.Keys(), []string{"sea", "shore"}) || st.check() != nil {
+			t.Error(backend.name+" DeleteRange Wrong", st.Keys())
+		}
+		if st.DeleteRange("shore", "sea") != 0 || st.DeleteRange("s", "sea") != 1 || st.Size() != 1 {
+			t.Error(backend.name + " DeleteRange Wrong")
+		}
+	}
+}
+
+// The copying API of TrieST and TST, on their own types
+type cloner[T any] interface {
+	stringST
+	Clone() T
+	Equal(other T) bool
+	EqualShape(other T) bool
+	Clear()
+}
+
+// Clones st, which must hold "she", and checks that the clone equals st,
+// changes apart from it and can be cleared
+func checkClone[T cloner[T]](t *testing.T, name string, st T) {
+	t.Helper()
+	c := st.Clone()
+	if !c.Equal(st) || !st.Equal(c) || !c.EqualShape(st) || c.check() != nil {
+		t.Error(name + " Clone Wrong")
+	}
+	c.Put("shellfish", 9)
+	c.Delete("she")
+	if c.Equal(st) || c.EqualShape(st) || !st.Contains("she") || st.Contains("shellfish") {
+		t.Error(name + " Clone Shares Storage")
+	}
+	c.Clear()
+	if c.Size() != 0 || len(c.Keys()) != 0 || c.Contains("") || c.check() != nil {
+		t.Error(name + " Clear Wrong")
+	}
+	c.Put("she", 1)
+	if c.Equal(st) || c.Size() != 1 || c.check() != nil {
+		t.Error(name + " Put After Clear Wrong")
+	}
+}
+
+func Test5(t *testing.T) {
+	trie, tst := NewTrieST(), NewTST()
+	for i, key := range strings.Fields("she sells sea shells by the sea shore") {
+		trie.Put(key, i)
+		tst.Put(key, i)
+	}
+	trie.Put("", 7)
+	tst.Put("", 7)
+	checkClone(t, "TrieST", trie)
+	checkClone(t, "TST", tst)
+
+	// the same pairs put in byte order: a trie comes out the same,
+	// a ternary search trie in another shape
+	sortedTrie, sortedTST := NewTrieST(), NewTST()
+	for _, key := range tst.Keys() {
+		sortedTrie.Put(key, tst.Get(key))
+		sortedTST.Put(key, tst.Get(key))
+	}
+	if !sortedTrie.Equal(trie) || !sortedTrie.EqualShape(trie) {
+		t.Error("TrieST Equal Wrong")
+	}
+	if !sortedTST.Equal(tst) || sortedTST.EqualShape(tst) {
+		t.Error("TST Equal Wrong")
+	}
+	sortedTST.Put("sea", 0)
+	if sortedTST.Equal(tst) {
+		t.Error("TST Equal Wrong")
+	}
+}
+
+func FuzzTrieST(f *testing.F) {
+	f.Add([]byte("abcdefghijklmnopqrstuvwxyz"))
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		apply(t, NewTrieST(), ops)
+	})
+}
+
+func FuzzTST(f *testing.F) {
+	f.Add([]byte("abcdefghijklmnopqrstuvwxyz"))
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		apply(t, NewTST(), ops)
+	})
+}
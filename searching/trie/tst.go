@@ -0,0 +1,471 @@
+package trie
+
+import (
+	"fmt"
+	"slices"
+)
+
+type tstNode struct {
+	c                byte
+	val              int
+	ok               bool     // whether a key ends at this node
+	left, mid, right *tstNode // left and right hold other bytes at the same position
+}
+
+// A ternary search trie: every node holds one byte and three links, to the
+// smaller and larger bytes at the same position and to the next position.
+// Far less memory than TrieST for large alphabets, at the cost of a few
+// byte comparisons per step.
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/TST.java.html
+type TST struct {
+	root     *tstNode
+	n        int
+	emptyVal int  // the value of the empty key, which has no node
+	emptyOk  bool // whether the empty key is in the table
+}
+
+func NewTST() *TST {
+	return new(TST)
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *TST) Size() int {
+	return t.n
+}
+
+// Returns the node of the last byte of a non-empty key, nil if no key starts with it
+func (t *TST) get(node *tstNode, key string, d int) *tstNode {
+	for node != nil {
+		if key[d] < node.c {
+			node = node.left
+		} else if key[d] > node.c {
+			node = node.right
+		} else if d < len(key)-1 {
+			node = node.mid
+			d++
+		} else {
+			return node
+		}
+	}
+	return nil
+}
+
+// Get value by key, return 0 if not exist
+func (t *TST) Get(key string) int {
+	if key == "" {
+		return t.emptyVal
+	}
+	node := t.get(t.root, key, 0)
+	if node == nil {
+		return 0
+	}
+	return node.val
+}
+
+// Return true if the key exists in the symbol table
+func (t *TST) Contains(key string) bool {
+	if key == "" {
+		return t.emptyOk
+	}
+	node := t.get(t.root, key, 0)
+	return node != nil && node.ok
+}
+
+func (t *TST) put(node *tstNode, key string, val int, d int) *tstNode {
+	if node == nil {
+		node = &tstNode{c: key[d]}
+	}
+	if key[d] < node.c {
+		node.left = t.put(node.left, key, val, d)
+	} else if key[d] > node.c {
+		node.right = t.put(node.right, key, val, d)
+	} else if d < len(key)-1 {
+		node.mid = t.put(node.mid, key, val, d+1)
+	} else {
+		if !node.ok {
+			t.n++
+		}
+		node.val, node.ok = val, true
+	}
+	return node
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *TST) Put(key string, val int) {
+	if key == "" {
+		if !t.emptyOk {
+			t.n++
+		}
+		t.emptyVal, t.emptyOk = val, true
+		return
+	}
+	t.root = t.put(t.root, key, val, 0)
+}
+
+// Joins the left and right subtrees of a removed node: every byte on the
+// left is smaller than every byte on the right
+func (t *TST) join(left *tstNode, right *tstNode) *tstNode {
+	if left == nil {
+		return right
+	}
+	node := left
+	for node.right != nil {
+		node = node.right
+	}
+	node.right = right
+	return left
+}
+
+// Removes the key below node; a node left with neither a key nor a middle
+// link is replaced by its siblings
+func (t *TST) delete(node *tstNode, key string, d int) *tstNode {
+	if node == nil {
+		return nil
+	}
+	if key[d] < node.c {
+		node.left = t.delete(node.left, key, d)
+	} else if key[d] > node.c {
+		node.right = t.delete(node.right, key, d)
+	} else if d < len(key)-1 {
+		node.mid = t.delete(node.mid, key, d+1)
+	} else {
+		if node.ok {
+			t.n--
+		}
+		node.val, node.ok = 0, false
+	}
+	if !node.ok && node.mid == nil {
+		return t.join(node.left, node.right)
+	}
+	return node
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *TST) Delete(key string) {
+	if key == "" {
+		if t.emptyOk {
+			t.n--
+		}
+		t.emptyVal, t.emptyOk = 0, false
+		return
+	}
+	t.root = t.delete(t.root, key, 0)
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *TST) Remove(key string) (val int, ok bool) {
+	if !t.Contains(key) {
+		return 0, false
+	}
+	val = t.Get(key)
+	t.Delete(key)
+	return val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the trie is empty
+func (t *TST) PopMin() (key string, val int, ok bool) {
+	if t.n == 0 {
+		return "", 0, false
+	}
+	// a key sorts before the keys it is a prefix of
+	var path []byte
+	if !t.emptyOk {
+		node := t.root
+		for {
+			for node.left != nil {
+				node = node.left
+			}
+			path = append(path, node.c)
+			if node.ok {
+				break
+			}
+			node = node.mid
+		}
+	}
+	key = string(path)
+	val, _ = t.Remove(key)
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the trie is empty
+func (t *TST) PopMax() (key string, val int, ok bool) {
+	if t.n == 0 {
+		return "", 0, false
+	}
+	var path []byte
+	for node := t.root; node != nil; node = node.mid {
+		for node.right != nil {
+			node = node.right
+		}
+		path = append(path, node.c)
+	}
+	key = string(path)
+	val, _ = t.Remove(key)
+	return key, val, true
+}
+
+// Returns the number of keys below node
+func (t *TST) count(node *tstNode) int {
+	if node == nil {
+		return 0
+	}
+	res := t.count(node.left) + t.count(node.mid) + t.count(node.right)
+	if node.ok {
+		res++
+	}
+	return res
+}
+
+// Removes the keys in [lo, hi] below node, which holds byte d of its keys.
+// checkLo is set while the path to the parent of node is a prefix of lo, so
+// that keys below it may be less than lo, and checkHi likewise for hi.
+func (t *TST) deleteRange(node *tstNode, lo string, hi string, d int, checkLo bool, checkHi bool) *tstNode {
+	if node == nil {
+		return nil
+	}
+	// past the end of lo every key is greater than it, past the end of hi too
+	checkLo = checkLo && d < len(lo)
+	if checkHi && d == len(hi) {
+		return node
+	}
+	if !checkLo && !checkHi {
+		t.n -= t.count(node)
+		return nil
+	}
+	// smaller bytes at this position only matter if they may reach lo,
+	// larger ones if they may reach hi
+	if !checkLo || node.c > lo[d] {
+		node.left = t.deleteRange(node.left, lo, hi, d, checkLo, checkHi)
+	}
+	if !checkHi || node.c < hi[d] {
+		node.right = t.deleteRange(node.right, lo, hi, d, checkLo, checkHi)
+	}
+	if (!checkLo || node.c >= lo[d]) && (!checkHi || node.c <= hi[d]) {
+		// the key ending here is a prefix of hi, and of lo it is lo itself or less
+		if node.ok && (!checkLo || node.c > lo[d] || d == len(lo)-1) {
+			node.val, node.ok = 0, false
+			t.n--
+		}
+		node.mid = t.deleteRange(node.mid, lo, hi, d+1, checkLo && node.c == lo[d], checkHi && node.c == hi[d])
+	}
+	if !node.ok && node.mid == nil {
+		return t.join(node.left, node.right)
+	}
+	return node
+}
+
+// Removes every key in [lo, hi] and returns how many there were. Only the
+// paths of lo and hi are searched; every subtree between them is dropped
+// whole, at a cost of one step per node removed.
+func (t *TST) DeleteRange(lo string, hi string) int {
+	if lo > hi {
+		return 0
+	}
+	n := t.n
+	if lo == "" && t.emptyOk {
+		t.emptyVal, t.emptyOk = 0, false
+		t.n--
+	}
+	t.root = t.deleteRange(t.root, lo, hi, 0, true, true)
+	return n - t.n
+}
+
+// Appends every key below node to res in byte order,
+// prefix being the path to the parent of node
+func (t *TST) collect(node *tstNode, prefix []byte, res *[]string) {
+	if node == nil {
+		return
+	}
+	t.collect(node.left, prefix, res)
+	if node.ok {
+		*res = append(*res, string(append(prefix, node.c)))
+	}
+	t.collect(node.mid, append(prefix, node.c), res)
+	t.collect(node.right, prefix, res)
+}
+
+// Returns all keys in the symbol table in byte order
+func (t *TST) Keys() []string {
+	return t.KeysWithPrefix("")
+}
+
+// Returns all keys that start with prefix, in byte order
+func (t *TST) KeysWithPrefix(prefix string) []string {
+	var res []string
+	if prefix == "" {
+		if t.emptyOk {
+			res = append(res, "")
+		}
+		t.collect(t.root, nil, &res)
+		return res
+	}
+	node := t.get(t.root, prefix, 0)
+	if node == nil {
+		return nil
+	}
+	if node.ok {
+		res = append(res, prefix)
+	}
+	t.collect(node.mid, []byte(prefix), &res)
+	return res
+}
+
+func (t *TST) match(node *tstNode, prefix []byte, pattern string, res *[]string) {
+	if node == nil {
+		return
+	}
+	d, c := len(prefix), pattern[len(prefix)]
+	if c == '.' || c < node.c {
+		t.match(node.left, prefix, pattern, res)
+	}
+	if c == '.' || c == node.c {
+		if d == len(pattern)-1 {
+			if node.ok {
+				*res = append(*res, string(append(prefix, node.c)))
+			}
+		} else {
+			t.match(node.mid, append(prefix, node.c), pattern, res)
+		}
+	}
+	if c == '.' || c > node.c {
+		t.match(node.right, prefix, pattern, res)
+	}
+}
+
+// Returns all keys of the same length as pattern that match it,
+// where '.' matches any byte, in byte order
+func (t *TST) KeysThatMatch(pattern string) []string {
+	if pattern == "" {
+		if t.emptyOk {
+			return []string{""}
+		}
+		return nil
+	}
+	var res []string
+	t.match(t.root, make([]byte, 0, len(pattern)), pattern, &res)
+	return res
+}
+
+// Returns the longest key that is a prefix of query, and false if there is none
+func (t *TST) LongestPrefixOf(query string) (string, bool) {
+	length, found := 0, t.emptyOk
+	node, d := t.root, 0
+	for node != nil && d < len(query) {
+		if query[d] < node.c {
+			node = node.left
+		} else if query[d] > node.c {
+			node = node.right
+		} else {
+			d++
+			if node.ok {
+				length, found = d, true
+			}
+			node = node.mid
+		}
+	}
+	return query[:length], found
+}
+
+// Returns a deep copy of the trie with the same shape. O(n) for n nodes.
+func (t *TST) Clone() *TST {
+	c := *t
+	c.root = t.clone(t.root)
+	return &c
+}
+
+func (t *TST) clone(node *tstNode) *tstNode {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.left, copied.mid, copied.right = t.clone(node.left), t.clone(node.mid), t.clone(node.right)
+	return &copied
+}
+
+// Reports whether the two tries hold the same key-value pairs, whatever
+// their shapes. The keys of both are listed and every value looked up.
+func (t *TST) Equal(other *TST) bool {
+	if t.n != other.n {
+		return false
+	}
+	keys := t.Keys()
+	if !slices.Equal(keys, other.Keys()) {
+		return false
+	}
+	for _, key := range keys {
+		if t.Get(key) != other.Get(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether the two tries are identical node for node: the same
+// bytes and values at the same places. Meant for test assertions, e.g.
+// that a Clone reproduced the trie exactly.
+func (t *TST) EqualShape(other *TST) bool {
+	return t.emptyOk == other.emptyOk && t.emptyVal == other.emptyVal && t.equalShape(t.root, other.root)
+}
+
+func (t *TST) equalShape(a *tstNode, b *tstNode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.c == b.c && a.ok == b.ok && a.val == b.val &&
+		t.equalShape(a.left, b.left) && t.equalShape(a.mid, b.mid) && t.equalShape(a.right, b.right)
+}
+
+// Removes every key
+func (t *TST) Clear() {
+	*t = TST{}
+}
+
+// Checks the invariants of the trie: the key count, byte order among
+// siblings and that every node without a key leads to one
+func (t *TST) check() error {
+	count, err := t.checkNode(t.root, -1, R)
+	if err != nil {
+		return err
+	}
+	if t.emptyOk {
+		count++
+	} else if t.emptyVal != 0 {
+		return fmt.Errorf("empty key absent but holds value %d", t.emptyVal)
+	}
+	if count != t.n {
+		return fmt.Errorf("trie holds %d keys, size is %d", count, t.n)
+	}
+	return nil
+}
+
+// lo and hi bound the bytes allowed in the subtree, exclusive.
+// Returns the number of keys below node.
+func (t *TST) checkNode(node *tstNode, lo int, hi int) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+	if int(node.c) <= lo || int(node.c) >= hi {
+		return 0, fmt.Errorf("byte %q is out of order", node.c)
+	}
+	if !node.ok && node.mid == nil {
+		return 0, fmt.Errorf("node %q leads to no key", node.c)
+	}
+	if !node.ok && node.val != 0 {
+		return 0, fmt.Errorf("node %q without a key holds value %d", node.c, node.val)
+	}
+	count := 0
+	if node.ok {
+		count++
+	}
+	for _, child := range []struct {
+		node   *tstNode
+		lo, hi int
+	}{{node.left, lo, int(node.c)}, {node.mid, -1, R}, {node.right, int(node.c), hi}} {
+		c, err := t.checkNode(child.node, child.lo, child.hi)
+		if err != nil {
+			return 0, err
+		}
+		count += c
+	}
+	return count, nil
+}
@@ -0,0 +1,833 @@
+// A symbol table implemented with a 2-3 tree.
+// A 2-node holds one key and two children, a 3-node two keys and three
+// children, and every leaf is at the same depth. Insertion grows a leaf into
+// a temporary 4-node and splits it, pushing the middle key up; deletion
+// refills an emptied node by borrowing from or merging with a sibling.
+// A left-leaning red-black tree is this tree with every 3-node drawn as two
+// 2-nodes joined by a red link; RedBlackDump shows that correspondence.
+// https://algs4.cs.princeton.edu/33balanced/
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/RedBlackBST.java.html
+package twoThreeTree
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"algo/utils"
+)
+
+// A key-value pair, as returned by Floor, Ceiling and Select
+type Entry struct {
+	key int
+	val int
+}
+
+// Returns the key of the entry
+func (e *Entry) Key() int {
+	return e.key
+}
+
+// Returns the value of the entry
+func (e *Entry) Val() int {
+	return e.val
+}
+
+// A 2-node (one key) or 3-node (two keys). Between an insertion and the
+// split that follows it, a node briefly holds three keys (a 4-node).
+type Node struct {
+	keys     []int
+	vals     []int
+	children []*Node // nil for a leaf, otherwise one more than the keys
+	size     int     // number of keys in the subtree
+}
+
+// The struct represents an ordered symbol table of int key-value pairs.
+type TwoThreeTree struct {
+	root *Node
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+}
+
+// have such a helper function to avoid visiting nil node
+func (t *TwoThreeTree) size(node *Node) int {
+	if node == nil {
+		return 0
+	} else {
+		return node.size
+	}
+}
+
+// Recomputes the size of the node from its keys and children
+func (t *TwoThreeTree) resize(node *Node) {
+	node.size = len(node.keys)
+	for _, child := range node.children {
+		node.size += t.size(child)
+	}
+}
+
+// Returns an empty tree ordered by compare
+func NewWithComparator(compare utils.Comparator) *TwoThreeTree {
+	return &TwoThreeTree{comparator: compare}
+}
+
+// Returns the number of keys of the node less than key,
+// which is also the index of the child key belongs to
+func (t *TwoThreeTree) position(node *Node, key int) int {
+	i := 0
+	for i < len(node.keys) && t.comparator.Compare(node.keys[i], key) < 0 {
+		i++
+	}
+	return i
+}
+
+// Returns true if the node holds key at index i
+func (t *TwoThreeTree) holds(node *Node, i int, key int) bool {
+	return i < len(node.keys) && t.comparator.Compare(node.keys[i], key) == 0
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *TwoThreeTree) Size() int {
+	return t.size(t.root)
+}
+
+// Returns the number of levels below the root, -1 for an empty tree
+func (t *TwoThreeTree) Height() int {
+	h := -1
+	for node := t.root; node != nil; h++ {
+		if node.children == nil {
+			node = nil
+		} else {
+			node = node.children[0]
+		}
+	}
+	return h
+}
+
+// Returns the node holding key and its index there, nil if there is none
+func (t *TwoThreeTree) get(node *Node, key int) (*Node, int) {
+	for node != nil {
+		i := t.position(node, key)
+		if t.holds(node, i, key) {
+			return node, i
+		}
+		if node.children == nil {
+			return nil, 0
+		}
+		node = node.children[i]
+	}
+	return nil, 0
+}
+
+// Get value by key, return 0 if not exist
+func (t *TwoThreeTree) Get(key int) int {
+	node, i := t.get(t.root, key)
+	if node == nil {
+		return 0
+	}
+	return node.vals[i]
+}
+
+// Return true if the key exists in the symbol table
+func (t *TwoThreeTree) Contains(key int) bool {
+	node, _ := t.get(t.root, key)
+	return node != nil
+}
+
+// Splits a 4-node into two 2-nodes and returns them with the middle key
+func (t *TwoThreeTree) split(node *Node) (left *Node, midKey int, midVal int, right *Node) {
+	left = &Node{keys: []int{node.keys[0]}, vals: []int{node.vals[0]}}
+	right = &Node{keys: []int{node.keys[2]}, vals: []int{node.vals[2]}}
+	if node.children != nil {
+		left.children = []*Node{node.children[0], node.children[1]}
+		right.children = []*Node{node.children[2], node.children[3]}
+	}
+	t.resize(left)
+	t.resize(right)
+	return left, node.keys[1], node.vals[1], right
+}
+
+func (t *TwoThreeTree) put(node *Node, key int, val int) {
+	i := t.position(node, key)
+	if t.holds(node, i, key) {
+		node.vals[i] = val
+		return
+	}
+	if node.children == nil {
+		// a leaf grows by one key
+		node.keys = insertAt(node.keys, i, key)
+		node.vals = insertAt(node.vals, i, val)
+	} else {
+		t.put(node.children[i], key, val)
+		t.absorb(node, i)
+	}
+	t.resize(node)
+}
+
+// If child i of node became a 4-node, splits it and takes its middle key
+func (t *TwoThreeTree) absorb(node *Node, i int) {
+	child := node.children[i]
+	if len(child.keys) < 3 {
+		return
+	}
+	left, midKey, midVal, right := t.split(child)
+	node.keys = insertAt(node.keys, i, midKey)
+	node.vals = insertAt(node.vals, i, midVal)
+	node.children[i] = left
+	node.children = insertAt(node.children, i+1, right)
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *TwoThreeTree) Put(key int, val int) {
+	if t.root == nil {
+		t.root = &Node{keys: []int{key}, vals: []int{val}, size: 1}
+		return
+	}
+	t.put(t.root, key, val)
+	if len(t.root.keys) == 3 {
+		// splitting the root is the only way the tree grows taller
+		left, midKey, midVal, right := t.split(t.root)
+		t.root = &Node{keys: []int{midKey}, vals: []int{midVal}, children: []*Node{left, right}}
+		t.resize(t.root)
+	}
+}
+
+// Removes key from the subtree. A node left without keys is repaired by
+// its parent; the root is repaired by Delete.
+func (t *TwoThreeTree) delete(node *Node, key int) {
+	i := t.position(node, key)
+	if node.children == nil {
+		if t.holds(node, i, key) {
+			node.keys = removeAt(node.keys, i)
+			node.vals = removeAt(node.vals, i)
+			node.size--
+		}
+		return
+	}
+	if t.holds(node, i, key) {
+		// swap in the successor, then delete it from the right subtree
+		succ := node.children[i+1]
+		for succ.children != nil {
+			succ = succ.children[0]
+		}
+		node.keys[i], node.vals[i] = succ.keys[0], succ.vals[0]
+		key = succ.keys[0]
+		i++
+	}
+	t.delete(node.children[i], key)
+	if len(node.children[i].keys) == 0 {
+		t.fix(node, i)
+	}
+	t.resize(node)
+}
+
+// Refills the empty child i of node: borrow a key through the parent from
+// a sibling 3-node, or else merge the child with a sibling 2-node, which
+// takes a key from the parent
+func (t *TwoThreeTree) fix(node *Node, i int) {
+	child := node.children[i]
+	if i > 0 && len(node.children[i-1].keys) == 2 {
+		left := node.children[i-1]
+		child.keys = []int{node.keys[i-1]}
+		child.vals = []int{node.vals[i-1]}
+		node.keys[i-1], node.vals[i-1] = left.keys[1], left.vals[1]
+		left.keys, left.vals = left.keys[:1], left.vals[:1]
+		if child.children != nil {
+			child.children = []*Node{left.children[2], child.children[0]}
+			left.children = left.children[:2]
+		}
+		t.resize(left)
+		t.resize(child)
+	} else if i+1 < len(node.children) && len(node.children[i+1].keys) == 2 {
+		right := node.children[i+1]
+		child.keys = []int{node.keys[i]}
+		child.vals = []int{node.vals[i]}
+		node.keys[i], node.vals[i] = right.keys[0], right.vals[0]
+		right.keys, right.vals = removeAt(right.keys, 0), removeAt(right.vals, 0)
+		if child.children != nil {
+			child.children = []*Node{child.children[0], right.children[0]}
+			right.children = removeAt(right.children, 0)
+		}
+		t.resize(right)
+		t.resize(child)
+	} else if i > 0 {
+		left := node.children[i-1]
+		left.keys = append(left.keys, node.keys[i-1])
+		left.vals = append(left.vals, node.vals[i-1])
+		left.children = append(left.children, child.children...)
+		node.keys, node.vals = removeAt(node.keys, i-1), removeAt(node.vals, i-1)
+		node.children = removeAt(node.children, i)
+		t.resize(left)
+	} else {
+		right := node.children[i+1]
+		right.keys = insertAt(right.keys, 0, node.keys[i])
+		right.vals = insertAt(right.vals, 0, node.vals[i])
+		if child.children != nil {
+			right.children = insertAt(right.children, 0, child.children[0])
+		}
+		node.keys, node.vals = removeAt(node.keys, i), removeAt(node.vals, i)
+		node.children = removeAt(node.children, i)
+		t.resize(right)
+	}
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *TwoThreeTree) Delete(key int) {
+	if !t.Contains(key) {
+		return
+	}
+	t.delete(t.root, key)
+	if len(t.root.keys) == 0 {
+		// the root lost its only key, the tree gets one level shorter
+		if t.root.children == nil {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+}
+
+func (t *TwoThreeTree) findMin(node *Node) *Node {
+	for node != nil && node.children != nil {
+		node = node.children[0]
+	}
+	return node
+}
+
+func (t *TwoThreeTree) findMax(node *Node) *Node {
+	for node != nil && node.children != nil {
+		node = node.children[len(node.children)-1]
+	}
+	return node
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (t *TwoThreeTree) DeleteMin() {
+	if t.Size() == 0 {
+		return
+	}
+	t.Delete(t.findMin(t.root).keys[0])
+}
+
+// Removes the largest key and associated value from the symbol table
+func (t *TwoThreeTree) DeleteMax() {
+	if t.Size() == 0 {
+		return
+	}
+	maxNode := t.findMax(t.root)
+	t.Delete(maxNode.keys[len(maxNode.keys)-1])
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *TwoThreeTree) Remove(key int) (val int, ok bool) {
+	node, i := t.get(t.root, key)
+	if node == nil {
+		return 0, false
+	}
+	val = node.vals[i]
+	t.Delete(key)
+	return val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the tree is empty
+func (t *TwoThreeTree) PopMin() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Min()
+	t.DeleteMin()
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the tree is empty
+func (t *TwoThreeTree) PopMax() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Max()
+	t.DeleteMax()
+	return key, val, true
+}
+
+// Joins two trees of heights hl and hr, -1 for an empty one, with a key
+// between them: every key of left is less than key and every key of right
+// greater. The shorter tree is hung from the spine of the taller one at its
+// own height, like a new key, and the 4-nodes this leaves on the way back
+// up are split as in Put. O(|hl-hr|+1). Returns the tree and its height.
+func (t *TwoThreeTree) join(left *Node, hl int, key int, val int, right *Node, hr int) (*Node, int) {
+	var root *Node
+	h := max(hl, hr)
+	if hl == hr {
+		root = &Node{keys: []int{key}, vals: []int{val}}
+		if left != nil {
+			root.children = []*Node{left, right}
+		}
+		t.resize(root)
+		return root, h + 1
+	}
+	if hl > hr {
+		t.joinRight(left, hl, key, val, right, hr)
+		root = left
+	} else {
+		t.joinLeft(left, hl, key, val, right, hr)
+		root = right
+	}
+	if len(root.keys) == 3 {
+		l, midKey, midVal, r := t.split(root)
+		root = &Node{keys: []int{midKey}, vals: []int{midVal}, children: []*Node{l, r}}
+		t.resize(root)
+		h++
+	}
+	return root, h
+}
+
+// Appends key and right, of height hr, to the right spine of node, of height h > hr
+func (t *TwoThreeTree) joinRight(node *Node, h int, key int, val int, right *Node, hr int) {
+	if h == hr+1 {
+		node.keys = append(node.keys, key)
+		node.vals = append(node.vals, val)
+		if right != nil {
+			node.children = append(node.children, right)
+		}
+	} else {
+		last := len(node.children) - 1
+		t.joinRight(node.children[last], h-1, key, val, right, hr)
+		t.absorb(node, last)
+	}
+	t.resize(node)
+}
+
+// Prepends left, of height hl, and key to the left spine of node, of height h > hl
+func (t *TwoThreeTree) joinLeft(left *Node, hl int, key int, val int, node *Node, h int) {
+	if h == hl+1 {
+		node.keys = insertAt(node.keys, 0, key)
+		node.vals = insertAt(node.vals, 0, val)
+		if left != nil {
+			node.children = insertAt(node.children, 0, left)
+		}
+	} else {
+		t.joinLeft(left, hl, key, val, node.children[0], h-1)
+		t.absorb(node, 0)
+	}
+	t.resize(node)
+}
+
+// Splits a subtree of height h into the keys before key and the rest, where
+// before means less than key, or less than or equal to it if orEqual is set.
+// Along the search path the keys and children on either side are joined
+// onto the two halves, O(log n) in all since the joined heights only grow.
+// Returns both halves with their heights.
+func (t *TwoThreeTree) splitAt(node *Node, h int, key int, orEqual bool) (*Node, int, *Node, int) {
+	if node == nil {
+		return nil, -1, nil, -1
+	}
+	i := t.position(node, key)
+	if orEqual && t.holds(node, i, key) {
+		i++
+	}
+	child := func(j int) *Node {
+		if node.children == nil {
+			return nil
+		}
+		return node.children[j]
+	}
+	left, hl, right, hr := t.splitAt(child(i), h-1, key, orEqual)
+	for j := i - 1; j >= 0; j-- {
+		left, hl = t.join(child(j), h-1, node.keys[j], node.vals[j], left, hl)
+	}
+	for j := i; j < len(node.keys); j++ {
+		right, hr = t.join(right, hr, node.keys[j], node.vals[j], child(j+1), h-1)
+	}
+	return left, hl, right, hr
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// The range comes off as one piece of two splits and the leftovers are
+// joined by height, O(log n) however many keys go.
+func (t *TwoThreeTree) DeleteRange(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	left, hl, rest, hr := t.splitAt(t.root, t.Height(), lo, false)
+	middle, _, right, hr := t.splitAt(rest, hr, hi, true)
+	if right == nil {
+		t.root = left
+	} else {
+		// the smallest key of right becomes the key joining the two sides
+		first := t.findMin(right)
+		key, val := first.keys[0], first.vals[0]
+		_, _, right, hr = t.splitAt(right, hr, key, true)
+		t.root, _ = t.join(left, hl, key, val, right, hr)
+	}
+	return t.size(middle)
+}
+
+func (t *TwoThreeTree) Min() (key int, val int) {
+	minNode := t.findMin(t.root)
+	return minNode.keys[0], minNode.vals[0]
+}
+
+func (t *TwoThreeTree) Max() (key int, val int) {
+	maxNode := t.findMax(t.root)
+	last := len(maxNode.keys) - 1
+	return maxNode.keys[last], maxNode.vals[last]
+}
+
+// Returns the entry with the largest key in the subtree less than or equal to key.
+func (t *TwoThreeTree) floor(node *Node, key int) *Entry {
+	if node == nil {
+		return nil
+	}
+	// j keys of the node are less than or equal to key
+	j := t.position(node, key)
+	if t.holds(node, j, key) {
+		return &Entry{node.keys[j], node.vals[j]}
+	}
+	if node.children != nil {
+		if r := t.floor(node.children[j], key); r != nil {
+			return r
+		}
+	}
+	if j > 0 {
+		return &Entry{node.keys[j-1], node.vals[j-1]}
+	}
+	return nil
+}
+
+// Returns the entry with the largest key in the symbol table less than or equal to key.
+func (t *TwoThreeTree) Floor(key int) *Entry {
+	return t.floor(t.root, key)
+}
+
+// Returns the entry with the smallest key in the subtree greater than or equal to key.
+func (t *TwoThreeTree) ceiling(node *Node, key int) *Entry {
+	if node == nil {
+		return nil
+	}
+	j := t.position(node, key)
+	if t.holds(node, j, key) {
+		return &Entry{node.keys[j], node.vals[j]}
+	}
+	if node.children != nil {
+		if r := t.ceiling(node.children[j], key); r != nil {
+			return r
+		}
+	}
+	if j < len(node.keys) {
+		return &Entry{node.keys[j], node.vals[j]}
+	}
+	return nil
+}
+
+// Returns the entry with the smallest key in the symbol table greater than or equal to key.
+func (t *TwoThreeTree) Ceiling(key int) *Entry {
+	return t.ceiling(t.root, key)
+}
+
+// Return the entry in the symbol table whose rank is k
+func (t *TwoThreeTree) Select(k int) *Entry {
+	if k < 0 || k >= t.Size() {
+		return nil
+	}
+	node := t.root
+	for {
+		for i := 0; i <= len(node.keys); i++ {
+			if node.children != nil {
+				if k < t.size(node.children[i]) {
+					node = node.children[i]
+					break
+				}
+				k -= t.size(node.children[i])
+			}
+			if k == 0 {
+				return &Entry{node.keys[i], node.vals[i]}
+			}
+			k--
+		}
+	}
+}
+
+func (t *TwoThreeTree) rank(node *Node, key int) int {
+	if node == nil {
+		return 0
+	}
+	j := t.position(node, key)
+	res := j
+	if node.children == nil {
+		return res
+	}
+	for _, child := range node.children[:j] {
+		res += t.size(child)
+	}
+	if t.holds(node, j, key) {
+		return res + t.size(node.children[j])
+	}
+	return res + t.rank(node.children[j], key)
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (t *TwoThreeTree) Rank(key int) int {
+	return t.rank(t.root, key)
+}
+
+func (t *TwoThreeTree) rangekeys(node *Node, lo int, hi int, res *[]int) {
+	if node == nil {
+		return
+	}
+	for i := 0; i <= len(node.keys); i++ {
+		// child i holds the keys between keys[i-1] and keys[i]
+		if node.children != nil &&
+			(i == len(node.keys) || t.comparator.Compare(lo, node.keys[i]) < 0) &&
+			(i == 0 || t.comparator.Compare(hi, node.keys[i-1]) > 0) {
+			t.rangekeys(node.children[i], lo, hi, res)
+		}
+		if i < len(node.keys) && t.comparator.Compare(lo, node.keys[i]) <= 0 && t.comparator.Compare(node.keys[i], hi) <= 0 {
+			*res = append(*res, node.keys[i])
+		}
+	}
+}
+
+// Returns all keys in the symbol table as an Iterable
+func (t *TwoThreeTree) Keys() []int {
+	if t.root == nil {
+		return nil
+	}
+	lo, _ := t.Min()
+	hi, _ := t.Max()
+	return t.RangeKeys(lo, hi)
+}
+
+// Returns all keys in the symbol table in the given range.
+func (t *TwoThreeTree) RangeKeys(lo int, hi int) []int {
+	var res []int
+	t.rangekeys(t.root, lo, hi, &res)
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (t *TwoThreeTree) RangeSize(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	if t.Contains(hi) {
+		return t.Rank(hi) - t.Rank(lo) + 1
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
+// Returns the tree level by level, one line per level, e.g.
+//
+//	[3]
+//	[1 2] [4]
+func (t *TwoThreeTree) Dump() string {
+	var lines []string
+	level := []*Node{}
+	if t.root != nil {
+		level = append(level, t.root)
+	}
+	for len(level) != 0 {
+		var words []string
+		var next []*Node
+		for _, node := range level {
+			words = append(words, fmt.Sprint(node.keys))
+			next = append(next, node.children...)
+		}
+		lines = append(lines, strings.Join(words, " "))
+		level = next
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the equivalent left-leaning red-black tree level by level, with
+// red nodes marked by a '*'. Each 3-node [a b] becomes b with a red left
+// child a; so the tree above is drawn as
+//
+//	3
+//	2 4
+//	1*
+func (t *TwoThreeTree) RedBlackDump() string {
+	type rbNode struct {
+		key         int
+		red         bool
+		left, right *rbNode
+	}
+	var convert func(node *Node) *rbNode
+	convert = func(node *Node) *rbNode {
+		if node == nil {
+			return nil
+		}
+		child := func(i int) *rbNode {
+			if node.children == nil {
+				return nil
+			}
+			return convert(node.children[i])
+		}
+		if len(node.keys) == 1 {
+			return &rbNode{key: node.keys[0], left: child(0), right: child(1)}
+		}
+		red := &rbNode{key: node.keys[0], red: true, left: child(0), right: child(1)}
+		return &rbNode{key: node.keys[1], left: red, right: child(2)}
+	}
+	var lines []string
+	level := []*rbNode{}
+	if root := convert(t.root); root != nil {
+		level = append(level, root)
+	}
+	for len(level) != 0 {
+		var words []string
+		var next []*rbNode
+		for _, node := range level {
+			word := fmt.Sprint(node.key)
+			if node.red {
+				word += "*"
+			}
+			words = append(words, word)
+			for _, child := range []*rbNode{node.left, node.right} {
+				if child != nil {
+					next = append(next, child)
+				}
+			}
+		}
+		lines = append(lines, strings.Join(words, " "))
+		level = next
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns a deep copy of the tree with the same nodes and sizes.
+// The copy keeps the comparator. O(n).
+func (t *TwoThreeTree) Clone() *TwoThreeTree {
+	return &TwoThreeTree{root: t.clone(t.root), comparator: t.comparator}
+}
+
+func (t *TwoThreeTree) clone(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	copied := &Node{keys: slices.Clone(node.keys), vals: slices.Clone(node.vals), size: node.size}
+	if node.children != nil {
+		copied.children = make([]*Node, len(node.children))
+		for i, child := range node.children {
+			copied.children[i] = t.clone(child)
+		}
+	}
+	return copied
+}
+
+// Appends the pairs of the subtree to res in key order
+func (t *TwoThreeTree) entries(node *Node, res []Entry) []Entry {
+	if node == nil {
+		return res
+	}
+	for i := range node.keys {
+		if node.children != nil {
+			res = t.entries(node.children[i], res)
+		}
+		res = append(res, Entry{node.keys[i], node.vals[i]})
+	}
+	if node.children != nil {
+		res = t.entries(node.children[len(node.keys)], res)
+	}
+	return res
+}
+
+// Reports whether the two trees hold the same key-value pairs in the same
+// order, whatever their shapes. O(n) time and space.
+func (t *TwoThreeTree) Equal(other *TwoThreeTree) bool {
+	return t.Size() == other.Size() && slices.Equal(t.entries(t.root, nil), other.entries(other.root, nil))
+}
+
+// Reports whether the two trees are identical node for node: the same keys
+// and values at the same places. Meant for test assertions, e.g. that a
+// Clone reproduced the tree exactly.
+func (t *TwoThreeTree) EqualShape(other *TwoThreeTree) bool {
+	return t.equalShape(t.root, other.root)
+}
+
+func (t *TwoThreeTree) equalShape(a *Node, b *Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !slices.Equal(a.keys, b.keys) || !slices.Equal(a.vals, b.vals) || len(a.children) != len(b.children) {
+		return false
+	}
+	for i := range a.children {
+		if !t.equalShape(a.children[i], b.children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes every key; the comparator stays
+func (t *TwoThreeTree) Clear() {
+	t.root = nil
+}
+
+// Inserts v at index i of s, shifting the rest to the right
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+// Returns s without the element at index i
+func removeAt[T any](s []T, i int) []T {
+	return append(s[:i], s[i+1:]...)
+}
+
+// Checks the invariants of the tree: only 2- and 3-nodes, symmetric order,
+// subtree sizes and every leaf at the same depth
+func (t *TwoThreeTree) check() error {
+	return t.checkNode(t.root, nil, nil, 0, t.Height())
+}
+
+// lo and hi bound the keys allowed in the subtree, nil meaning unbounded
+func (t *TwoThreeTree) checkNode(node *Node, lo *int, hi *int, depth int, height int) error {
+	if node == nil {
+		return nil
+	}
+	if len(node.keys) < 1 || len(node.keys) > 2 || len(node.vals) != len(node.keys) {
+		return fmt.Errorf("node at depth %d holds %d keys", depth, len(node.keys))
+	}
+	for i, key := range node.keys {
+		if (lo != nil && t.comparator.Compare(key, *lo) <= 0) || (hi != nil && t.comparator.Compare(key, *hi) >= 0) ||
+			(i > 0 && t.comparator.Compare(node.keys[i-1], key) >= 0) {
+			return fmt.Errorf("key %d is out of order", key)
+		}
+	}
+	want := len(node.keys)
+	for _, child := range node.children {
+		want += t.size(child)
+	}
+	if node.size != want {
+		return fmt.Errorf("node %v has size %d, want %d", node.keys, node.size, want)
+	}
+	if node.children == nil {
+		if depth != height {
+			return fmt.Errorf("leaf %v at depth %d, want %d", node.keys, depth, height)
+		}
+		return nil
+	}
+	if len(node.children) != len(node.keys)+1 {
+		return fmt.Errorf("node %v has %d children", node.keys, len(node.children))
+	}
+	for i, child := range node.children {
+		childLo, childHi := lo, hi
+		if i > 0 {
+			childLo = &node.keys[i-1]
+		}
+		if i < len(node.keys) {
+			childHi = &node.keys[i]
+		}
+		if child == nil {
+			return fmt.Errorf("node %v is missing child %d", node.keys, i)
+		}
+		if err := t.checkNode(child, childLo, childHi, depth+1, height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
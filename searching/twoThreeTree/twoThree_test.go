@@ -0,0 +1,128 @@
+package twoThreeTree
+
+import (
+	"algo/searching/conformance"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	var tree *TwoThreeTree = new(TwoThreeTree)
+	if tree.Size() != 0 || tree.Height() != -1 || tree.Ceiling(1) != nil || tree.Floor(1) != nil {
+		t.Fail()
+	}
+	tree.Put(1, 3)
+	tree.Put(1, 4)
+	tree.Put(2, 3)
+	tree.Put(4, 5)
+	tree.Put(10, 1)
+	if tree.Size() != 4 || !tree.Contains(1) || tree.Get(1) != 4 || tree.Get(0) != 0 {
+		t.Error("Put Wrong")
+	}
+	if e := tree.Floor(3); e == nil || e.Key() != 2 || e.Val() != 3 {
+		t.Error("Floor Wrong")
+	}
+	if e := tree.Ceiling(3); e == nil || e.Key() != 4 {
+		t.Error("Ceiling Wrong")
+	}
+	if e := tree.Select(2); e == nil || e.Key() != 4 || tree.Rank(4) != 2 || tree.Rank(5) != 3 {
+		t.Error("Select/Rank Wrong")
+	}
+	tree.DeleteMin()
+	tree.DeleteMax()
+	tree.Delete(2)
+	minkey, minval := tree.Min()
+	if tree.Size() != 1 || minkey != 4 || minval != 5 {
+		t.Error("Delete Wrong")
+	}
+}
+
+// ascending inserts split along the right spine: the height stays logarithmic
+func Test2(t *testing.T) {
+	tree := new(TwoThreeTree)
+	for i := 0; i < 1000; i++ {
+		tree.Put(i, i)
+		if i%97 == 0 {
+			if err := tree.check(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if tree.Height() > 9 {
+		t.Error("Tree Too Tall " + strconv.Itoa(tree.Height()))
+	}
+	if !reflect.DeepEqual(tree.RangeKeys(500, 505), []int{500, 501, 502, 503, 504, 505}) {
+		t.Error("Range Wrong")
+	}
+	for i := 0; i < 1000; i += 2 {
+		tree.Delete(i)
+	}
+	if tree.Size() != 500 || tree.Select(0).Key() != 1 || tree.Rank(999) != 499 || tree.check() != nil {
+		t.Error("Delete Wrong")
+	}
+	for i := 1; i < 1000; i += 2 {
+		tree.Delete(i)
+	}
+	if tree.Size() != 0 || tree.root != nil {
+		t.Error("Delete All Wrong")
+	}
+}
+
+func Test3(t *testing.T) {
+	tree := new(TwoThreeTree)
+	if tree.Dump() != "" || tree.RedBlackDump() != "" {
+		t.Error("Empty Dump Wrong")
+	}
+	for _, key := range []int{1, 2, 3, 4} {
+		tree.Put(key, 0)
+	}
+	// 1 2 3 splits into [2] over [1] [3], then 4 joins [3]
+	if tree.Dump() != "[2]\n[1] [3 4]" {
+		t.Error("Dump Wrong\n" + tree.Dump())
+	}
+	if tree.RedBlackDump() != "2\n1 4\n3*" {
+		t.Error("RedBlackDump Wrong\n" + tree.RedBlackDump())
+	}
+	tree.Put(5, 0)
+	if tree.Dump() != "[2 4]\n[1] [3] [5]" {
+		t.Error("Split Wrong\n" + tree.Dump())
+	}
+	if tree.RedBlackDump() != "4\n2* 5\n1 3" {
+		t.Error("RedBlackDump Wrong\n" + tree.RedBlackDump())
+	}
+	// deleting 5 empties a leaf, which merges with [3] and takes 4 from the parent
+	tree.Delete(5)
+	if tree.Dump() != "[2]\n[1] [3 4]" {
+		t.Error("Merge Wrong\n" + tree.Dump())
+	}
+	// deleting 1 borrows 3 through the parent from the sibling 3-node
+	tree.Delete(1)
+	if tree.Dump() != "[3]\n[2] [4]" {
+		t.Error("Borrow Wrong\n" + tree.Dump())
+	}
+}
+
+// check reports a stale size and leaves it as it was
+func Test4(t *testing.T) {
+	tree := new(TwoThreeTree)
+	for i := 0; i < 10; i++ {
+		tree.Put(i, i)
+	}
+	tree.root.size++
+	if tree.check() == nil || tree.check() == nil || tree.root.size != 11 {
+		t.Error("check Wrong")
+	}
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapt[*Entry](func() *TwoThreeTree { return new(TwoThreeTree) }, (*TwoThreeTree).check))
+}
+
+func TestReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Entry](func() *TwoThreeTree { return NewWithComparator(conformance.Descending) }, (*TwoThreeTree).check))
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Entry](func() *TwoThreeTree { return new(TwoThreeTree) }, (*TwoThreeTree).check))
+}
@@ -0,0 +1,624 @@
+// A symbol table implemented with a weight-balanced tree, BB[α].
+// The weight of a subtree is its size plus one, and every node keeps the
+// weight of each child within α of its own, so the height stays below
+// log_{1/(1-α)}(n+1). Updates rebalance every node on the search path on the
+// way back up with a single or double rotation, following the structure of
+// Adams' functional sets; no height is stored, only the sizes also used by
+// Rank and Select.
+// https://en.wikipedia.org/wiki/Weight-balanced_tree
+// https://groups.csail.mit.edu/mac/users/adams/BB/
+package weightBalancedTree
+
+import (
+	"fmt"
+
+	"algo/utils"
+)
+
+// The α used by the zero value
+const DefaultAlpha = 0.25
+
+// The range of α for which a single or double rotation always restores balance
+const (
+	MinAlpha = 2.0 / 11
+	MaxAlpha = 0.2928 // just below 1 - √2/2
+)
+
+type Node struct {
+	key         int
+	val         int
+	size        int
+	left, right *Node
+}
+
+// Returns the key stored in the node
+func (n *Node) Key() int {
+	return n.key
+}
+
+// Returns the value stored in the node
+func (n *Node) Val() int {
+	return n.val
+}
+
+// Returns the left child, nil if there is none
+func (n *Node) Left() *Node {
+	return n.left
+}
+
+// Returns the right child, nil if there is none
+func (n *Node) Right() *Node {
+	return n.right
+}
+
+// The struct represents an ordered symbol table of int key-value pairs.
+// The zero value is an empty tree using DefaultAlpha.
+type WeightBalancedTree struct {
+	root  *Node
+	alpha float64
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
+}
+
+// Returns an empty tree with the given α, which must be in (MinAlpha, MaxAlpha]
+func New(alpha float64) *WeightBalancedTree {
+	if !(alpha > MinAlpha && alpha <= MaxAlpha) {
+		panic("weightBalancedTree: alpha must be in (2/11, 1-√2/2)")
+	}
+	return &WeightBalancedTree{alpha: alpha}
+}
+
+// Returns the α of the tree, DefaultAlpha for the zero value
+func (t *WeightBalancedTree) balanceFactor() float64 {
+	if t.alpha == 0 {
+		return DefaultAlpha
+	}
+	return t.alpha
+}
+
+// have such a helper function to avoid visiting nil node
+func (t *WeightBalancedTree) size(node *Node) int {
+	if node == nil {
+		return 0
+	} else {
+		return node.size
+	}
+}
+
+// The weight of a subtree, one more than its size so that empty subtrees weigh something
+func (t *WeightBalancedTree) weight(node *Node) int {
+	return t.size(node) + 1
+}
+
+// Returns true if a subtree of weight part may hang below one of weight whole
+func (t *WeightBalancedTree) heavyEnough(part int, whole int) bool {
+	return float64(part) >= t.balanceFactor()*float64(whole)
+}
+
+// Returns an empty tree using DefaultAlpha, ordered by compare
+func NewWithComparator(compare utils.Comparator) *WeightBalancedTree {
+	return &WeightBalancedTree{comparator: compare}
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (t *WeightBalancedTree) Size() int {
+	return t.size(t.root)
+}
+
+func (t *WeightBalancedTree) resize(node *Node) {
+	node.size = 1 + t.size(node.left) + t.size(node.right)
+}
+
+func (t *WeightBalancedTree) rotateLeft(node *Node) *Node {
+	x := node.right
+	node.right = x.left
+	x.left = node
+	t.resize(node)
+	t.resize(x)
+	return x
+}
+
+func (t *WeightBalancedTree) rotateRight(node *Node) *Node {
+	x := node.left
+	node.left = x.right
+	x.right = node
+	t.resize(node)
+	t.resize(x)
+	return x
+}
+
+// Restores the balance of a node whose children were balanced before one
+// of them gained or lost a key. A light side is fixed by a single rotation,
+// or by a double one when the inner grandchild is the heavy one.
+func (t *WeightBalancedTree) balance(node *Node) *Node {
+	t.resize(node)
+	w := t.weight(node)
+	if !t.heavyEnough(t.weight(node.left), w) {
+		r := node.right
+		if float64(t.weight(r.left)) > float64(t.weight(r))/(2-t.balanceFactor()) {
+			node.right = t.rotateRight(r)
+		}
+		return t.rotateLeft(node)
+	}
+	if !t.heavyEnough(t.weight(node.right), w) {
+		l := node.left
+		if float64(t.weight(l.right)) > float64(t.weight(l))/(2-t.balanceFactor()) {
+			node.left = t.rotateLeft(l)
+		}
+		return t.rotateRight(node)
+	}
+	return node
+}
+
+// Returns the node by key
+func (t *WeightBalancedTree) get(n *Node, key int) *Node {
+	if n == nil {
+		return nil
+	} else {
+		cmp := t.comparator.Compare(key, n.key)
+		if cmp == 0 {
+			return n
+		} else if cmp < 0 {
+			return t.get(n.left, key)
+		} else {
+			return t.get(n.right, key)
+		}
+	}
+}
+
+// Get value by key, return 0 if not exist
+func (t *WeightBalancedTree) Get(key int) int {
+	n := t.get(t.root, key)
+	if n != nil {
+		return n.val
+	} else {
+		return 0
+	}
+}
+
+// Return true if the key exists in the symbol table
+func (t *WeightBalancedTree) Contains(key int) bool {
+	n := t.get(t.root, key)
+	return n != nil
+}
+
+func (t *WeightBalancedTree) put(node *Node, key int, val int) *Node {
+	if node == nil {
+		return &Node{key: key, val: val, size: 1}
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp == 0 {
+		node.val = val
+		return node
+	} else if cmp < 0 {
+		node.left = t.put(node.left, key, val)
+	} else {
+		node.right = t.put(node.right, key, val)
+	}
+	return t.balance(node)
+}
+
+// Inserts the specified key-value pair into the symbol table
+func (t *WeightBalancedTree) Put(key int, val int) {
+	t.root = t.put(t.root, key, val)
+}
+
+// Removes the smallest node of the subtree, returning it and the new root
+func (t *WeightBalancedTree) deleteMin(node *Node) (*Node, *Node) {
+	if node.left == nil {
+		return node, node.right
+	}
+	var minNode *Node
+	minNode, node.left = t.deleteMin(node.left)
+	return minNode, t.balance(node)
+}
+
+func (t *WeightBalancedTree) delete(node *Node, key int) *Node {
+	if node == nil {
+		return nil
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp < 0 {
+		node.left = t.delete(node.left, key)
+	} else if cmp > 0 {
+		node.right = t.delete(node.right, key)
+	} else {
+		if node.left == nil {
+			return node.right
+		}
+		if node.right == nil {
+			return node.left
+		}
+		// the successor takes the place of the node
+		successor, right := t.deleteMin(node.right)
+		successor.left, successor.right = node.left, right
+		node = successor
+	}
+	return t.balance(node)
+}
+
+// Removes the key and associated value from the symbol table, if present
+func (t *WeightBalancedTree) Delete(key int) {
+	t.root = t.delete(t.root, key)
+}
+
+func (t *WeightBalancedTree) findMin(node *Node) *Node {
+	if node == nil {
+		return node
+	}
+
+	if node.left != nil {
+		return t.findMin(node.left)
+	}
+	return node
+}
+
+func (t *WeightBalancedTree) findMax(node *Node) *Node {
+	if node == nil {
+		return node
+	}
+
+	if node.right != nil {
+		return t.findMax(node.right)
+	}
+	return node
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (t *WeightBalancedTree) DeleteMin() {
+	if t.Size() == 0 {
+		return
+	}
+	t.Delete(t.findMin(t.root).key)
+}
+
+// Removes the largest key and associated value from the symbol table
+func (t *WeightBalancedTree) DeleteMax() {
+	if t.Size() == 0 {
+		return
+	}
+	t.Delete(t.findMax(t.root).key)
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (t *WeightBalancedTree) Remove(key int) (val int, ok bool) {
+	n := t.get(t.root, key)
+	if n == nil {
+		return 0, false
+	}
+	t.Delete(key)
+	return n.val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the tree is empty
+func (t *WeightBalancedTree) PopMin() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Min()
+	t.DeleteMin()
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the tree is empty
+func (t *WeightBalancedTree) PopMax() (key int, val int, ok bool) {
+	if t.Size() == 0 {
+		return 0, 0, false
+	}
+	key, val = t.Max()
+	t.DeleteMax()
+	return key, val, true
+}
+
+// Returns true if subtrees of weights a and b may be the two children of a node
+func (t *WeightBalancedTree) like(a int, b int) bool {
+	return t.heavyEnough(a, a+b) && t.heavyEnough(b, a+b)
+}
+
+// Joins left, node and right into one tree, where every key of left is
+// less than node's key and every key of right greater. The lighter side
+// goes down the spine of the heavier one until it meets a subtree it may
+// be a sibling of; on the way back up a single or double rotation fixes
+// each node that got too heavy on that side. This needs α ≤ 1-√2/2.
+// https://arxiv.org/abs/1602.02120 (Just Join for Parallel Ordered Sets)
+func (t *WeightBalancedTree) join(left *Node, node *Node, right *Node) *Node {
+	if t.like(t.weight(left), t.weight(right)) {
+		node.left, node.right = left, right
+		t.resize(node)
+		return node
+	}
+	if t.weight(left) > t.weight(right) {
+		left.right = t.join(left.right, node, right)
+		t.resize(left)
+		if t.like(t.weight(left.left), t.weight(left.right)) {
+			return left
+		}
+		r := left.right
+		if t.like(t.weight(left.left), t.weight(r.left)) && t.like(t.weight(left.left)+t.weight(r.left), t.weight(r.right)) {
+			return t.rotateLeft(left)
+		}
+		left.right = t.rotateRight(r)
+		return t.rotateLeft(left)
+	}
+	right.left = t.join(left, node, right.left)
+	t.resize(right)
+	if t.like(t.weight(right.left), t.weight(right.right)) {
+		return right
+	}
+	l := right.left
+	if t.like(t.weight(l.right), t.weight(right.right)) && t.like(t.weight(l.left), t.weight(l.right)+t.weight(right.right)) {
+		return t.rotateRight(right)
+	}
+	right.left = t.rotateLeft(l)
+	return t.rotateRight(right)
+}
+
+// Splits the subtree into the keys before key and the rest, where before
+// means less than key, or less than or equal to it if orEqual is set.
+// O(log n), joining the pieces cut off along the search path.
+func (t *WeightBalancedTree) split(node *Node, key int, orEqual bool) (*Node, *Node) {
+	if node == nil {
+		return nil, nil
+	}
+	cmp := t.comparator.Compare(node.key, key)
+	if cmp < 0 || (orEqual && cmp == 0) {
+		left, right := t.split(node.right, key, orEqual)
+		return t.join(node.left, node, left), right
+	}
+	left, right := t.split(node.left, key, orEqual)
+	return left, t.join(right, node, node.right)
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// Splitting at lo and after hi and joining the two outer pieces costs
+// O(log n), however many keys the middle piece takes with it.
+func (t *WeightBalancedTree) DeleteRange(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	left, rest := t.split(t.root, lo, false)
+	middle, right := t.split(rest, hi, true)
+	if right == nil {
+		t.root = left
+	} else {
+		// the smallest key of right becomes the node joining the two sides
+		minNode, right := t.deleteMin(right)
+		t.root = t.join(left, minNode, right)
+	}
+	return t.size(middle)
+}
+
+func (t *WeightBalancedTree) Min() (key int, val int) {
+	minNode := t.findMin(t.root)
+	return minNode.key, minNode.val
+}
+
+func (t *WeightBalancedTree) Max() (key int, val int) {
+	maxNode := t.findMax(t.root)
+	return maxNode.key, maxNode.val
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (t *WeightBalancedTree) floor(node *Node, key int) *Node {
+	if node == nil {
+		return node
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp == 0 {
+		return node
+	} else if cmp > 0 {
+		r := t.floor(node.right, key)
+		if r != nil {
+			return r
+		} else {
+			return node
+		}
+	} else {
+		return t.floor(node.left, key)
+	}
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (t *WeightBalancedTree) Floor(key int) *Node {
+	return t.floor(t.root, key)
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (t *WeightBalancedTree) ceiling(node *Node, key int) *Node {
+	if node == nil {
+		return node
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp == 0 {
+		return node
+	} else if cmp < 0 {
+		r := t.ceiling(node.left, key)
+		if r != nil {
+			return r
+		} else {
+			return node
+		}
+	} else {
+		return t.ceiling(node.right, key)
+	}
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (t *WeightBalancedTree) Ceiling(key int) *Node {
+	return t.ceiling(t.root, key)
+}
+
+func (t *WeightBalancedTree) selectHelper(node *Node, k int) *Node {
+	if node == nil {
+		return node
+	}
+	if t.size(node.left) == k { // say k = 0, should return the smallest
+		return node
+	} else if t.size(node.left) < k {
+		return t.selectHelper(node.right, k-1-t.size(node.left))
+	} else {
+		return t.selectHelper(node.left, k)
+	}
+}
+
+// Return the node in the symbol table whose rank is k
+func (t *WeightBalancedTree) Select(k int) *Node {
+	return t.selectHelper(t.root, k)
+}
+
+func (t *WeightBalancedTree) rank(node *Node, key int) int {
+	if node == nil {
+		return 0
+	}
+	cmp := t.comparator.Compare(key, node.key)
+	if cmp > 0 {
+		return t.size(node.left) + 1 + t.rank(node.right, key)
+	} else if cmp == 0 {
+		return t.size(node.left)
+	} else {
+		return t.rank(node.left, key)
+	}
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (t *WeightBalancedTree) Rank(key int) int {
+	return t.rank(t.root, key)
+}
+
+func (t *WeightBalancedTree) keys(node *Node, res *[]int) {
+	if node == nil {
+		return
+	} else {
+		t.keys(node.left, res)
+		*res = append(*res, node.key)
+		t.keys(node.right, res)
+	}
+}
+
+// Returns all keys in the symbol table as an Iterable
+func (t *WeightBalancedTree) Keys() []int {
+	var res []int
+	t.keys(t.root, &res)
+	return res
+}
+
+func (t *WeightBalancedTree) rangekeys(node *Node, lo int, hi int, res *[]int) {
+	if node == nil {
+		return
+	}
+	if t.comparator.Compare(lo, node.key) > 0 {
+		t.rangekeys(node.right, lo, hi, res)
+	} else if t.comparator.Compare(hi, node.key) < 0 {
+		t.rangekeys(node.left, lo, hi, res)
+	} else {
+		t.rangekeys(node.left, lo, hi, res)
+		*res = append(*res, node.key)
+		t.rangekeys(node.right, lo, hi, res)
+	}
+}
+
+// Returns all keys in the symbol table in the given range.
+func (t *WeightBalancedTree) RangeKeys(lo int, hi int) []int {
+	var res []int
+	t.rangekeys(t.root, lo, hi, &res)
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (t *WeightBalancedTree) RangeSize(lo int, hi int) int {
+	if t.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	if t.Contains(hi) {
+		return t.Rank(hi) - t.Rank(lo) + 1
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
+// Returns a deep copy of the tree with the same shape and sizes, so it
+// balances the same way from here on. The copy keeps α and the
+// comparator. O(n).
+func (t *WeightBalancedTree) Clone() *WeightBalancedTree {
+	return &WeightBalancedTree{root: t.clone(t.root), alpha: t.alpha, comparator: t.comparator}
+}
+
+func (t *WeightBalancedTree) clone(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.left, copied.right = t.clone(node.left), t.clone(node.right)
+	return &copied
+}
+
+// Pushes node and the chain of its left children onto the stack
+func pushLeft(stack []*Node, node *Node) []*Node {
+	for ; node != nil; node = node.left {
+		stack = append(stack, node)
+	}
+	return stack
+}
+
+// Reports whether the two trees hold the same key-value pairs in the same
+// order, whatever their shapes. Both are walked in order side by side, O(n).
+func (t *WeightBalancedTree) Equal(other *WeightBalancedTree) bool {
+	if t.Size() != other.Size() {
+		return false
+	}
+	s1, s2 := pushLeft(nil, t.root), pushLeft(nil, other.root)
+	for len(s1) != 0 {
+		a, b := s1[len(s1)-1], s2[len(s2)-1]
+		if a.key != b.key || a.val != b.val {
+			return false
+		}
+		s1 = pushLeft(s1[:len(s1)-1], a.right)
+		s2 = pushLeft(s2[:len(s2)-1], b.right)
+	}
+	return true
+}
+
+// Reports whether the two trees are identical node for node: the same keys
+// and values at the same places. Meant for test assertions, e.g. that a
+// Clone reproduced the tree exactly.
+func (t *WeightBalancedTree) EqualShape(other *WeightBalancedTree) bool {
+	return t.equalShape(t.root, other.root)
+}
+
+func (t *WeightBalancedTree) equalShape(a *Node, b *Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.key == b.key && a.val == b.val && t.equalShape(a.left, b.left) && t.equalShape(a.right, b.right)
+}
+
+// Removes every key; α and the comparator stay
+func (t *WeightBalancedTree) Clear() {
+	t.root = nil
+}
+
+// Checks the invariants of the tree: symmetric order, subtree sizes and
+// the weight balance of every node
+func (t *WeightBalancedTree) check() error {
+	return t.checkNode(t.root, nil, nil)
+}
+
+// lo and hi bound the keys allowed in the subtree, nil meaning unbounded
+func (t *WeightBalancedTree) checkNode(node *Node, lo *int, hi *int) error {
+	if node == nil {
+		return nil
+	}
+	if (lo != nil && t.comparator.Compare(node.key, *lo) <= 0) || (hi != nil && t.comparator.Compare(node.key, *hi) >= 0) {
+		return fmt.Errorf("key %d is out of order", node.key)
+	}
+	if node.size != 1+t.size(node.left)+t.size(node.right) {
+		return fmt.Errorf("node %d has size %d", node.key, node.size)
+	}
+	w := t.weight(node)
+	if !t.heavyEnough(t.weight(node.left), w) || !t.heavyEnough(t.weight(node.right), w) {
+		return fmt.Errorf("node %d is out of balance: weights %d and %d", node.key, t.weight(node.left), t.weight(node.right))
+	}
+	if err := t.checkNode(node.left, lo, &node.key); err != nil {
+		return err
+	}
+	return t.checkNode(node.right, &node.key, hi)
+}
@@ -0,0 +1,91 @@
+package weightBalancedTree
+
+import (
+	"algo/searching/conformance"
+	"algo/searching/traversal"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test1(t *testing.T) {
+	var tree *WeightBalancedTree = new(WeightBalancedTree)
+	if tree.Size() != 0 {
+		t.Fail()
+	}
+	tree.Put(1, 3)
+	tree.Put(1, 4)
+	tree.Put(2, 3)
+	tree.Put(4, 5)
+	tree.Put(10, 1)
+	if tree.Size() != 4 || !tree.Contains(1) || tree.Get(1) != 4 || tree.Get(0) != 0 {
+		t.Error("Put Wrong")
+	}
+	if n := tree.Floor(3); n == nil || n.Key() != 2 {
+		t.Error("Floor Wrong")
+	}
+	if n := tree.Ceiling(3); n == nil || n.Key() != 4 {
+		t.Error("Ceiling Wrong")
+	}
+	if n := tree.Select(2); n == nil || n.Key() != 4 || tree.Rank(4) != 2 || tree.Rank(5) != 3 {
+		t.Error("Select/Rank Wrong")
+	}
+	tree.DeleteMin()
+	tree.DeleteMax()
+	tree.Delete(2)
+	minkey, minval := tree.Min()
+	if tree.Size() != 1 || minkey != 4 || minval != 5 {
+		t.Error("Delete Wrong")
+	}
+}
+
+// ascending inserts are rotated into shape, the height stays below log_{1/(1-α)}(n+1)
+func Test2(t *testing.T) {
+	tree := New(0.25)
+	for i := 0; i < 1<<12; i++ {
+		tree.Put(i, i)
+	}
+	// (4/3)^29 > 2^12
+	if h := traversal.Height(tree.root); h > 29 {
+		t.Error("Height Too Large " + strconv.Itoa(h))
+	}
+	if err := tree.check(); err != nil {
+		t.Error(err)
+	}
+	for i := 0; i < 1<<12-10; i++ {
+		tree.Delete(i)
+	}
+	if tree.Size() != 10 || tree.check() != nil {
+		t.Error("Delete Wrong")
+	}
+	if !reflect.DeepEqual(tree.RangeKeys(4093, 5000), []int{4093, 4094, 4095}) {
+		t.Error("Range Wrong")
+	}
+}
+
+func Test3(t *testing.T) {
+	for _, alpha := range []float64{0.1, 0.3, 0.5} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Alpha Accepted " + strconv.FormatFloat(alpha, 'f', -1, 64))
+				}
+			}()
+			New(alpha)
+		}()
+	}
+}
+
+func TestConformance(t *testing.T) {
+	for _, alpha := range []float64{0.19, 0.25, MaxAlpha} {
+		conformance.Run(t, conformance.Adapt[*Node](func() *WeightBalancedTree { return New(alpha) }, (*WeightBalancedTree).check))
+	}
+}
+
+func TestReversed(t *testing.T) {
+	conformance.RunReversed(t, conformance.Adapt[*Node](func() *WeightBalancedTree { return NewWithComparator(conformance.Descending) }, (*WeightBalancedTree).check))
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, conformance.Adapt[*Node](func() *WeightBalancedTree { return new(WeightBalancedTree) }, (*WeightBalancedTree).check))
+}
@@ -1,27 +1,31 @@
 package main
 
-import "fmt"
-
-/**
-Delete(key int)
-Min() int
-Max() int
-Floor(k int) int
-Ceiling(k int) int
-Select(k int)             // Return the key in the symbol table whose rank is k
-Rank(key int)             // Return the number of keys in the symbol table strictly less than `key`
-Keys()                    // Returns all keys in the symbol table as an Iterable
-RangeKeys(lo int, hi int) // Returns all keys in the symbol table in the given range.
-RangeSize(lo int, hi int) // Returns the number of keys in the symbol table in the given range.
-**/
+import (
+	"algo/sorting"
+	"algo/utils"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// A symbol table implemented with an ordered array and binary search.
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/BinarySearchST.java.html
 
 type Node struct {
 	key int
 	val int
 }
 
+// Returns the key stored in the node
+func (n *Node) Key() int {
+	return n.key
+}
+
 type SortedArray struct {
 	array []Node
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
 }
 
 func NewSortedArray() *SortedArray {
@@ -29,6 +33,42 @@ func NewSortedArray() *SortedArray {
 	return arrObj
 }
 
+// Returns an empty array ordered by compare
+func NewSortedArrayWithComparator(compare utils.Comparator) *SortedArray {
+	return &SortedArray{comparator: compare}
+}
+
+// Builds a table from parallel slices of keys and values in O(n log n),
+// instead of the O(n^2) of one Put per key. The pairs are stably sorted by
+// key, so for a key given more than once the last value wins, as if each
+// pair had been Put in order.
+func FromUnsorted(keys []int, vals []int) *SortedArray {
+	return FromUnsortedWithComparator(keys, vals, nil)
+}
+
+// Same as FromUnsorted, for a table ordered by compare
+func FromUnsortedWithComparator(keys []int, vals []int, compare utils.Comparator) *SortedArray {
+	if len(keys) != len(vals) {
+		panic("FromUnsorted: keys and vals differ in length")
+	}
+	nodes := make([]Node, len(keys))
+	for i := range keys {
+		nodes[i] = Node{keys[i], vals[i]}
+	}
+	sorting.MergeTopDownFunc(nodes, func(a Node, b Node) int {
+		return compare.Compare(a.key, b.key)
+	})
+	arrObj := NewSortedArrayWithComparator(compare)
+	for i, node := range nodes {
+		// keep the last of every run of equal keys
+		if i+1 < len(nodes) && compare.Compare(nodes[i+1].key, node.key) == 0 {
+			continue
+		}
+		arrObj.array = append(arrObj.array, node)
+	}
+	return arrObj
+}
+
 // If the target is found,
 //   then the index ( = how many keys < k) is returned.
 // If the target is not found, then the index to be
@@ -39,9 +79,10 @@ func (self *SortedArray) BinarySearch(key int) int {
 	right := len(self.array) - 1
 	for left <= right {
 		mid := (left + right) / 2
-		if self.array[mid].key == key {
+		c := self.comparator.Compare(self.array[mid].key, key)
+		if c == 0 {
 			return mid
-		} else if self.array[mid].key < key {
+		} else if c < 0 {
 			left = mid + 1
 		} else {
 			right = mid - 1
@@ -50,13 +91,36 @@ func (self *SortedArray) BinarySearch(key int) int {
 	return left
 }
 
+// Reports whether the key at idx, the result of BinarySearch(key), is key itself
+func (self *SortedArray) found(idx int, key int) bool {
+	return idx < len(self.array) && self.comparator.Compare(self.array[idx].key, key) == 0
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (self *SortedArray) Size() int {
+	return len(self.array)
+}
+
 func (self *SortedArray) Contains(key int) bool {
 	idx := self.BinarySearch(key)
-	return idx < len(self.array) && self.array[idx].key == key
+	return self.found(idx, key)
+}
+
+// Get value by key, return 0 if not exist
+func (self *SortedArray) Get(key int) int {
+	idx := self.BinarySearch(key)
+	if self.found(idx, key) {
+		return self.array[idx].val
+	}
+	return 0
 }
 
 func (self *SortedArray) Put(key int, val int) {
 	idx := self.BinarySearch(key)
+	if self.found(idx, key) {
+		self.array[idx].val = val
+		return
+	}
 	// The append built-in function appends elements to the end of a slice.
 	//    If it has sufficient capacity, the destination is resliced to accommodate the new elements.
 	//    If it does not, a new underlying array will be allocated.
@@ -66,6 +130,216 @@ func (self *SortedArray) Put(key int, val int) {
 	self.array = append(self.array[:idx], append([]Node{Node{key, val}}, self.array[idx:]...)...)
 }
 
+// Calls f with the value of key and whether the key exists, then stores
+// the value f returns, or removes the key if f says not to keep it.
+// The key is binary searched once.
+func (self *SortedArray) Compute(key int, f func(old int, ok bool) (val int, keep bool)) {
+	idx := self.BinarySearch(key)
+	if self.found(idx, key) {
+		if val, keep := f(self.array[idx].val, true); keep {
+			self.array[idx].val = val
+		} else {
+			self.array = append(self.array[:idx], self.array[idx+1:]...)
+		}
+		return
+	}
+	if val, keep := f(0, false); keep {
+		self.array = append(self.array[:idx], append([]Node{{key, val}}, self.array[idx:]...)...)
+	}
+}
+
+// Returns the value of key and true if it exists; otherwise puts val and
+// returns it with false
+func (self *SortedArray) GetOrPut(key int, val int) (actual int, loaded bool) {
+	actual = val
+	self.Compute(key, func(old int, ok bool) (int, bool) {
+		if ok {
+			actual, loaded = old, true
+			return old, true
+		}
+		return val, true
+	})
+	return actual, loaded
+}
+
+// Puts val and returns the previous value of key and whether there was one
+func (self *SortedArray) Swap(key int, val int) (previous int, loaded bool) {
+	self.Compute(key, func(old int, ok bool) (int, bool) {
+		previous, loaded = old, ok
+		return val, true
+	})
+	return previous, loaded
+}
+
+// Removes the key and associated value from the symbol table, if present.
+// Returns the removed value and whether the key was there.
+func (self *SortedArray) Delete(key int) (val int, found bool) {
+	idx := self.BinarySearch(key)
+	if !self.found(idx, key) {
+		return 0, false
+	}
+	val = self.array[idx].val
+	self.array = append(self.array[:idx], self.array[idx+1:]...)
+	return val, true
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (self *SortedArray) DeleteMin() {
+	if len(self.array) == 0 {
+		return
+	}
+	self.array = self.array[1:]
+}
+
+// Removes the largest key and associated value from the symbol table
+func (self *SortedArray) DeleteMax() {
+	if len(self.array) == 0 {
+		return
+	}
+	self.array = self.array[:len(self.array)-1]
+}
+
+// Same as Delete, under the name every backend shares
+func (self *SortedArray) Remove(key int) (val int, ok bool) {
+	return self.Delete(key)
+}
+
+// Removes the smallest key and returns it with its value; false if the array is empty
+func (self *SortedArray) PopMin() (key int, val int, ok bool) {
+	if len(self.array) == 0 {
+		return 0, 0, false
+	}
+	node := self.array[0]
+	self.array = self.array[1:]
+	return node.key, node.val, true
+}
+
+// Removes the largest key and returns it with its value; false if the array is empty
+func (self *SortedArray) PopMax() (key int, val int, ok bool) {
+	if len(self.array) == 0 {
+		return 0, 0, false
+	}
+	node := self.array[len(self.array)-1]
+	self.array = self.array[:len(self.array)-1]
+	return node.key, node.val, true
+}
+
+// Removes every key in [lo, hi] and returns how many there were.
+// Two binary searches find the range, which is cut out in one copy of the
+// keys after it.
+func (self *SortedArray) DeleteRange(lo int, hi int) int {
+	if self.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	start, end := self.BinarySearch(lo), self.BinarySearch(hi)
+	if self.found(end, hi) {
+		end++
+	}
+	self.array = append(self.array[:start], self.array[end:]...)
+	return end - start
+}
+
+func (self *SortedArray) Min() (key int, val int) {
+	return self.array[0].key, self.array[0].val
+}
+
+func (self *SortedArray) Max() (key int, val int) {
+	last := self.array[len(self.array)-1]
+	return last.key, last.val
+}
+
+// Returns a copy of the node at idx, nil if idx is out of range
+func (self *SortedArray) nodeAt(idx int) *Node {
+	if idx < 0 || idx >= len(self.array) {
+		return nil
+	}
+	node := self.array[idx]
+	return &node
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (self *SortedArray) Floor(key int) *Node {
+	idx := self.BinarySearch(key)
+	if self.found(idx, key) {
+		return self.nodeAt(idx)
+	}
+	return self.nodeAt(idx - 1)
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (self *SortedArray) Ceiling(key int) *Node {
+	return self.nodeAt(self.BinarySearch(key))
+}
+
+// Return the node in the symbol table whose rank is k
+func (self *SortedArray) Select(k int) *Node {
+	return self.nodeAt(k)
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (self *SortedArray) Rank(key int) int {
+	return self.BinarySearch(key)
+}
+
+// Returns all keys in the symbol table as an Iterable
+func (self *SortedArray) Keys() []int {
+	var res []int
+	for _, node := range self.array {
+		res = append(res, node.key)
+	}
+	return res
+}
+
+// Returns all keys in the symbol table in the given range.
+func (self *SortedArray) RangeKeys(lo int, hi int) []int {
+	var res []int
+	for idx := self.BinarySearch(lo); idx < len(self.array) && self.comparator.Compare(self.array[idx].key, hi) <= 0; idx++ {
+		res = append(res, self.array[idx].key)
+	}
+	return res
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (self *SortedArray) RangeSize(lo int, hi int) int {
+	if self.comparator.Compare(lo, hi) > 0 {
+		return 0
+	}
+	if self.Contains(hi) {
+		return self.Rank(hi) - self.Rank(lo) + 1
+	}
+	return self.Rank(hi) - self.Rank(lo)
+}
+
+// Returns a copy of the array with its own storage and the same comparator
+func (self *SortedArray) Clone() *SortedArray {
+	return &SortedArray{array: slices.Clone(self.array), comparator: self.comparator}
+}
+
+// Reports whether the two arrays hold the same key-value pairs in the same order
+func (self *SortedArray) Equal(other *SortedArray) bool {
+	return slices.Equal(self.array, other.array)
+}
+
+// Same as Equal: a sorted array has a single layout for its contents
+func (self *SortedArray) EqualShape(other *SortedArray) bool {
+	return self.Equal(other)
+}
+
+// Removes every key; the comparator stays
+func (self *SortedArray) Clear() {
+	self.array = nil
+}
+
+// Checks that the keys are in strictly ascending order
+func (self *SortedArray) check() error {
+	for idx := 1; idx < len(self.array); idx++ {
+		if self.comparator.Compare(self.array[idx-1].key, self.array[idx].key) >= 0 {
+			return errors.New("keys are out of order at index " + strconv.Itoa(idx))
+		}
+	}
+	return nil
+}
+
 func (self *SortedArray) Print() {
 	fmt.Printf("\n")
 	for _, node := range self.array {
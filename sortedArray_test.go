@@ -0,0 +1,181 @@
+package main
+
+import (
+	"algo/searching/conformance"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// Put on an existing key replaces its value instead of inserting a duplicate
+func TestSortedArrayPut(t *testing.T) {
+	st := NewSortedArray()
+	for _, k := range []int{5, 1, 3, 5, 1} {
+		st.Put(k, k*10)
+	}
+	st.Put(3, 7)
+	if st.Size() != 3 || !reflect.DeepEqual(st.Keys(), []int{1, 3, 5}) || st.Get(3) != 7 || st.Get(4) != 0 {
+		t.Error("Put Wrong", st.Keys())
+	}
+}
+
+func TestSortedArrayOrdered(t *testing.T) {
+	st := NewSortedArray()
+	for _, k := range []int{9, 3, 5, 1, 7} {
+		st.Put(k, k*10)
+	}
+	if n := st.Floor(4); n == nil || n.key != 3 || st.Floor(0) != nil {
+		t.Error("Floor Wrong")
+	}
+	if n := st.Ceiling(4); n == nil || n.key != 5 || st.Ceiling(10) != nil {
+		t.Error("Ceiling Wrong")
+	}
+	if n := st.Select(1); n == nil || n.key != 3 || st.Select(5) != nil || st.Rank(4) != 2 {
+		t.Error("Select/Rank Wrong")
+	}
+	if !reflect.DeepEqual(st.RangeKeys(2, 7), []int{3, 5, 7}) || st.RangeSize(2, 7) != 3 || st.RangeSize(7, 2) != 0 {
+		t.Error("Range Wrong")
+	}
+	st.Delete(5)
+	st.DeleteMin()
+	st.DeleteMax()
+	if k, v := st.Min(); k != 3 || v != 30 || st.Contains(5) || st.Size() != 2 {
+		t.Error("Delete Wrong")
+	}
+	if k, _ := st.Max(); k != 7 {
+		t.Error("Max Wrong")
+	}
+}
+
+// FromUnsorted builds the same table as one Put per pair, duplicates included
+func TestFromUnsorted(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 1))
+	for n := 0; n < 200; n += 7 {
+		keys := make([]int, n)
+		vals := make([]int, n)
+		want := NewSortedArray()
+		reversed := NewSortedArrayWithComparator(conformance.Descending)
+		for i := range keys {
+			keys[i], vals[i] = r.IntN(n/2+1), i
+			want.Put(keys[i], vals[i])
+			reversed.Put(keys[i], vals[i])
+		}
+		got := FromUnsorted(keys, vals)
+		if !reflect.DeepEqual(got.array, want.array) || got.check() != nil {
+			t.Fatalf("n %d: FromUnsorted Wrong", n)
+		}
+		got = FromUnsortedWithComparator(keys, vals, conformance.Descending)
+		if !reflect.DeepEqual(got.array, reversed.array) || got.check() != nil {
+			t.Fatalf("n %d: FromUnsortedWithComparator Wrong", n)
+		}
+		got.Put(n, n)
+		if k, _ := got.Min(); k != n {
+			t.Fatalf("n %d: Comparator Lost", n)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Mismatched Lengths Accepted")
+		}
+	}()
+	FromUnsorted([]int{1, 2}, []int{1})
+}
+
+// Compute, GetOrPut, Swap and Delete against a map
+func TestCompute(t *testing.T) {
+	r := rand.New(rand.NewPCG(48, 48))
+	st := NewSortedArray()
+	ref := make(map[int]int)
+	for i := 0; i < 2000; i++ {
+		key, val := r.IntN(30), r.IntN(5)
+		switch r.IntN(4) {
+		case 0:
+			// add val, dropping the key when the sum reaches 0 mod 4
+			f := func(old int, ok bool) (int, bool) { return old + val, (old+val)%4 != 0 }
+			st.Compute(key, f)
+			if v, keep := f(ref[key], true); keep {
+				ref[key] = v
+			} else {
+				delete(ref, key)
+			}
+		case 1:
+			old, ok := ref[key]
+			if !ok {
+				old = val
+				ref[key] = val
+			}
+			if v, loaded := st.GetOrPut(key, val); v != old || loaded != ok {
+				t.Fatal("GetOrPut Wrong", i)
+			}
+		case 2:
+			old, ok := ref[key]
+			ref[key] = val
+			if v, loaded := st.Swap(key, val); v != old || loaded != ok {
+				t.Fatal("Swap Wrong", i)
+			}
+		case 3:
+			old, ok := ref[key]
+			delete(ref, key)
+			if v, found := st.Delete(key); v != old || found != ok {
+				t.Fatal("Delete Wrong", i)
+			}
+		}
+		if st.Size() != len(ref) || st.Get(key) != ref[key] || st.check() != nil {
+			t.Fatal("Size/Get Wrong", i)
+		}
+	}
+}
+
+func BenchmarkFromUnsorted(b *testing.B) {
+	for _, n := range []int{1e3, 1e5} {
+		keys := benchKeys("random", n)
+		vals := make([]int, n)
+		b.Run("FromUnsorted/n="+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FromUnsorted(keys, vals)
+			}
+		})
+		b.Run("Put/n="+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				st := NewSortedArray()
+				for j, key := range keys {
+					st.Put(key, vals[j])
+				}
+			}
+		})
+	}
+}
+
+// Clone copies the storage, Equal compares contents and Clear empties
+func TestClone(t *testing.T) {
+	st := NewSortedArray()
+	us := NewUnsortedArray()
+	for _, k := range []int{3, 1, 2} {
+		st.Put(k, k*10)
+		us.Put(k, k*10)
+	}
+	sc, uc := st.Clone(), us.Clone()
+	if !sc.Equal(st) || !sc.EqualShape(st) || !uc.Equal(us) || !uc.EqualShape(us) {
+		t.Error("Clone Wrong")
+	}
+	sc.Put(2, 0)
+	uc.Put(2, 0)
+	if st.Get(2) != 20 || us.Get(2) != 20 || sc.Equal(st) || uc.Equal(us) {
+		t.Error("Clone Wrong")
+	}
+	// the same pairs in other slots are equal but not the same shape
+	other := NewUnsortedArray()
+	for _, k := range []int{1, 2, 3} {
+		other.Put(k, k*10)
+	}
+	if !other.Equal(us) || other.EqualShape(us) {
+		t.Error("Equal Wrong")
+	}
+	st.Clear()
+	us.Clear()
+	if st.Size() != 0 || us.Size() != 0 || sc.Size() != 3 || !st.Equal(NewSortedArray()) {
+		t.Error("Clear Wrong")
+	}
+}
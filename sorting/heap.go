@@ -0,0 +1,43 @@
+package sorting
+
+import (
+	"cmp"
+)
+
+// Sorts a in ascending order with heapsort: build a max-heap in place, then
+// repeatedly swap its root to the end of the shrinking heap. Not stable,
+// in place, O(n log n) comparisons in the worst case.
+// https://algs4.cs.princeton.edu/24pq/
+func Heap[T cmp.Ordered](a []T) {
+	HeapFunc(a, cmp.Compare[T])
+}
+
+// Sorts a with heapsort according to cmp. Not stable.
+func HeapFunc[T any](a []T, cmp func(a T, b T) int) {
+	heapSort(a, cmp)
+}
+
+func heapSort[T any](a []T, cmp func(a T, b T) int) {
+	for k := len(a)/2 - 1; k >= 0; k-- {
+		sink(a, k, len(a), cmp)
+	}
+	for n := len(a) - 1; n > 0; n-- {
+		a[0], a[n] = a[n], a[0]
+		sink(a, 0, n, cmp)
+	}
+}
+
+// Moves a[k] down the heap a[:n] until both its children are no larger
+func sink[T any](a []T, k int, n int, cmp func(a T, b T) int) {
+	for 2*k+1 < n {
+		j := 2*k + 1
+		if j+1 < n && cmp(a[j], a[j+1]) < 0 {
+			j++
+		}
+		if cmp(a[k], a[j]) >= 0 {
+			return
+		}
+		a[k], a[j] = a[j], a[k]
+		k = j
+	}
+}
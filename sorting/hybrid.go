@@ -0,0 +1,25 @@
+package sorting
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// Subarrays up to this length are insertion sorted by Hybrid
+const hybridCutoff = 12
+
+// Sorts a in ascending order with an introsort: 3-way quicksort that
+// insertion sorts small subarrays and falls back to heapsort when the
+// recursion gets deeper than 2·log2(n), which bounds the worst case.
+// Not stable, O(log n) extra space, O(n log n) comparisons in the worst
+// case. The default choice when stability is not needed; use
+// MergeTopDown when it is.
+// https://en.wikipedia.org/wiki/Introsort
+func Hybrid[T cmp.Ordered](a []T) {
+	HybridFunc(a, cmp.Compare[T])
+}
+
+// Sorts a with an introsort according to cmp. Not stable.
+func HybridFunc[T any](a []T, cmp func(a T, b T) int) {
+	quickSort(a, 0, len(a), 2*bits.Len(uint(len(a))), cmp)
+}
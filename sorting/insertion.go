@@ -0,0 +1,32 @@
+package sorting
+
+import (
+	"cmp"
+)
+
+// Sorts a in ascending order by insertion: every element is shifted left
+// past the larger ones before it. Stable, in place, O(n²) comparisons in
+// the worst case but O(n) on input that is already nearly sorted, which
+// makes it the sort of choice for small or almost sorted slices.
+// https://algs4.cs.princeton.edu/21elementary/
+func Insertion[T cmp.Ordered](a []T) {
+	InsertionFunc(a, cmp.Compare[T])
+}
+
+// Sorts a by insertion according to cmp. Stable.
+func InsertionFunc[T any](a []T, cmp func(a T, b T) int) {
+	insertionRange(a, 0, len(a), cmp)
+}
+
+// Sorts a[lo:hi] by insertion
+func insertionRange[T any](a []T, lo int, hi int, cmp func(a T, b T) int) {
+	for i := lo + 1; i < hi; i++ {
+		v := a[i]
+		j := i
+		// strictly greater only, so equal elements keep their order
+		for ; j > lo && cmp(v, a[j-1]) < 0; j-- {
+			a[j] = a[j-1]
+		}
+		a[j] = v
+	}
+}
@@ -0,0 +1,74 @@
+package sorting
+
+import (
+	"cmp"
+)
+
+// Subarrays up to this length are insertion sorted by MergeTopDown
+const mergeCutoff = 12
+
+// Sorts a in ascending order with top-down mergesort: sort each half
+// recursively, then merge them. Small subarrays are insertion sorted and
+// the merge is skipped when the halves are already in order. Stable,
+// O(n log n) comparisons, O(n) extra space.
+// https://algs4.cs.princeton.edu/22mergesort/
+func MergeTopDown[T cmp.Ordered](a []T) {
+	MergeTopDownFunc(a, cmp.Compare[T])
+}
+
+// Sorts a with top-down mergesort according to cmp. Stable.
+func MergeTopDownFunc[T any](a []T, cmp func(a T, b T) int) {
+	aux := make([]T, len(a))
+	mergeSort(a, aux, 0, len(a), cmp)
+}
+
+func mergeSort[T any](a []T, aux []T, lo int, hi int, cmp func(a T, b T) int) {
+	if hi-lo <= mergeCutoff {
+		insertionRange(a, lo, hi, cmp)
+		return
+	}
+	mid := lo + (hi-lo)/2
+	mergeSort(a, aux, lo, mid, cmp)
+	mergeSort(a, aux, mid, hi, cmp)
+	if cmp(a[mid], a[mid-1]) >= 0 {
+		return
+	}
+	merge(a, aux, lo, mid, hi, cmp)
+}
+
+// Sorts a in ascending order with bottom-up mergesort: merge runs of
+// length 1, 2, 4, ... across the whole slice, with no recursion. Stable,
+// O(n log n) comparisons, O(n) extra space.
+// https://algs4.cs.princeton.edu/22mergesort/
+func MergeBottomUp[T cmp.Ordered](a []T) {
+	MergeBottomUpFunc(a, cmp.Compare[T])
+}
+
+// Sorts a with bottom-up mergesort according to cmp. Stable.
+func MergeBottomUpFunc[T any](a []T, cmp func(a T, b T) int) {
+	aux := make([]T, len(a))
+	for width := 1; width < len(a); width *= 2 {
+		for lo := 0; lo+width < len(a); lo += 2 * width {
+			merge(a, aux, lo, lo+width, min(lo+2*width, len(a)), cmp)
+		}
+	}
+}
+
+// Merges the sorted runs a[lo:mid] and a[mid:hi], taking from the left run
+// on ties so that the merge is stable
+func merge[T any](a []T, aux []T, lo int, mid int, hi int, cmp func(a T, b T) int) {
+	copy(aux[lo:hi], a[lo:hi])
+	i, j := lo, mid
+	for k := lo; k < hi; k++ {
+		if i == mid {
+			a[k] = aux[j]
+			j++
+		} else if j == hi || cmp(aux[j], aux[i]) >= 0 {
+			a[k] = aux[i]
+			i++
+		} else {
+			a[k] = aux[j]
+			j++
+		}
+	}
+}
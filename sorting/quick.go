@@ -0,0 +1,85 @@
+package sorting
+
+import (
+	"cmp"
+)
+
+// Sorts a in ascending order with Dijkstra's 3-way quicksort: partition
+// around a pivot into the elements less than, equal to and greater than it,
+// and recurse on the outer parts only, so slices with many duplicate keys
+// sort in linear time. The pivot is the median of the first, middle and
+// last elements. Not stable, O(log n) extra space for the recursion on the
+// smaller part; O(n log n) comparisons on average but O(n²) on adversarial
+// input, which Hybrid guards against.
+// https://algs4.cs.princeton.edu/23quicksort/
+func Quick3Way[T cmp.Ordered](a []T) {
+	Quick3WayFunc(a, cmp.Compare[T])
+}
+
+// Sorts a with 3-way quicksort according to cmp. Not stable.
+func Quick3WayFunc[T any](a []T, cmp func(a T, b T) int) {
+	quickSort(a, 0, len(a), -1, cmp)
+}
+
+// Sorts a[lo:hi]. Once depth reaches 0 the rest is heapsorted, a negative
+// depth never does; subarrays up to hybridCutoff are insertion sorted
+// when depth is not negative.
+func quickSort[T any](a []T, lo int, hi int, depth int, cmp func(a T, b T) int) {
+	for hi-lo > 1 {
+		if depth >= 0 && hi-lo <= hybridCutoff {
+			insertionRange(a, lo, hi, cmp)
+			return
+		}
+		if depth == 0 {
+			heapSort(a[lo:hi], cmp)
+			return
+		}
+		depth--
+		lt, gt := partition3(a, lo, hi, cmp)
+		// recurse into the smaller part and loop on the larger one
+		if lt-lo < hi-gt {
+			quickSort(a, lo, lt, depth, cmp)
+			lo = gt
+		} else {
+			quickSort(a, gt, hi, depth, cmp)
+			hi = lt
+		}
+	}
+}
+
+// Moves the median of a[i], a[j] and a[k] to a[i]
+func medianToFront[T any](a []T, i int, j int, k int, cmp func(a T, b T) int) {
+	if cmp(a[j], a[i]) < 0 {
+		a[i], a[j] = a[j], a[i]
+	}
+	if cmp(a[k], a[j]) < 0 {
+		a[j], a[k] = a[k], a[j]
+		if cmp(a[j], a[i]) < 0 {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	// now a[i] <= a[j] <= a[k]
+	a[i], a[j] = a[j], a[i]
+}
+
+// Partitions a[lo:hi] around a pivot so that a[lo:lt] < pivot,
+// a[lt:gt] == pivot and a[gt:hi] > pivot
+func partition3[T any](a []T, lo int, hi int, cmp func(a T, b T) int) (int, int) {
+	medianToFront(a, lo, lo+(hi-lo)/2, hi-1, cmp)
+	pivot := a[lo]
+	lt, i, gt := lo, lo+1, hi
+	for i < gt {
+		c := cmp(a[i], pivot)
+		if c < 0 {
+			a[lt], a[i] = a[i], a[lt]
+			lt++
+			i++
+		} else if c > 0 {
+			gt--
+			a[i], a[gt] = a[gt], a[i]
+		} else {
+			i++
+		}
+	}
+	return lt, gt
+}
@@ -0,0 +1,32 @@
+package sorting
+
+import (
+	"cmp"
+)
+
+// Sorts a in ascending order with Shellsort: insertion sorts over elements
+// h apart for the decreasing gaps h of Knuth's sequence 1, 4, 13, 40, ...
+// so that elements travel far in few moves. Not stable, in place,
+// O(n^(3/2)) comparisons in the worst case.
+// https://algs4.cs.princeton.edu/21elementary/
+func Shell[T cmp.Ordered](a []T) {
+	ShellFunc(a, cmp.Compare[T])
+}
+
+// Sorts a with Shellsort according to cmp. Not stable.
+func ShellFunc[T any](a []T, cmp func(a T, b T) int) {
+	h := 1
+	for h < len(a)/3 {
+		h = 3*h + 1
+	}
+	for ; h >= 1; h /= 3 {
+		for i := h; i < len(a); i++ {
+			v := a[i]
+			j := i
+			for ; j >= h && cmp(v, a[j-h]) < 0; j -= h {
+				a[j] = a[j-h]
+			}
+			a[j] = v
+		}
+	}
+}
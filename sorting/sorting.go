@@ -0,0 +1,36 @@
+// Comparison sorts over slices, generic over ordered types.
+// Every algorithm comes in two forms: X sorts a slice of a cmp.Ordered type
+// in ascending order, XFunc sorts any slice with a comparison function
+// returning a negative number, zero or a positive number like cmp.Compare.
+//
+//	algorithm      time (worst)   extra space   stable
+//	Insertion      O(n²)          O(1)          yes
+//	Shell          O(n^(3/2))     O(1)          no
+//	MergeTopDown   O(n log n)     O(n)          yes
+//	MergeBottomUp  O(n log n)     O(n)          yes
+//	Quick3Way      O(n²)          O(log n)      no
+//	Heap           O(n log n)     O(1)          no
+//	Hybrid         O(n log n)     O(log n)      no
+//
+// A stable sort keeps elements that compare equal in their original order.
+// https://algs4.cs.princeton.edu/20sorting/
+package sorting
+
+import (
+	"cmp"
+)
+
+// Returns true if a is in ascending order
+func IsSorted[T cmp.Ordered](a []T) bool {
+	return IsSortedFunc(a, cmp.Compare[T])
+}
+
+// Returns true if a is in ascending order according to cmp
+func IsSortedFunc[T any](a []T, cmp func(a T, b T) int) bool {
+	for i := 1; i < len(a); i++ {
+		if cmp(a[i], a[i-1]) < 0 {
+			return false
+		}
+	}
+	return true
+}
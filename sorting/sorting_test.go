@@ -0,0 +1,143 @@
+package sorting
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+var sorts = []struct {
+	name   string
+	sort   func(a []int, cmp func(a int, b int) int)
+	stable bool
+}{
+	{"Insertion", InsertionFunc[int], true},
+	{"Shell", ShellFunc[int], false},
+	{"MergeTopDown", MergeTopDownFunc[int], true},
+	{"MergeBottomUp", MergeBottomUpFunc[int], true},
+	{"Quick3Way", Quick3WayFunc[int], false},
+	{"Heap", HeapFunc[int], false},
+	{"Hybrid", HybridFunc[int], false},
+}
+
+// every slice of length up to 7 over 4 values, which covers all orderings
+// with and without duplicates
+func Test1(t *testing.T) {
+	for n := 0; n <= 7; n++ {
+		total := 1
+		for i := 0; i < n; i++ {
+			total *= 4
+		}
+		for code := 0; code < total; code++ {
+			input := make([]int, n)
+			for i, c := 0, code; i < n; i, c = i+1, c/4 {
+				input[i] = c % 4
+			}
+			want := slices.Clone(input)
+			slices.Sort(want)
+			for _, s := range sorts {
+				a := slices.Clone(input)
+				s.sort(a, cmp.Compare[int])
+				if !slices.Equal(a, want) {
+					t.Fatalf("%s(%v) = %v", s.name, input, a)
+				}
+			}
+		}
+	}
+}
+
+// the stable sorts keep equal keys in input order. Elements are encoded as
+// key*1000 + position and compared by key only.
+func Test2(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 1))
+	byKey := func(a int, b int) int { return cmp.Compare(a/1000, b/1000) }
+	for _, n := range []int{10, 100, 999} {
+		input := make([]int, n)
+		for i := range input {
+			input[i] = r.IntN(5)*1000 + i
+		}
+		for _, s := range sorts {
+			if !s.stable {
+				continue
+			}
+			a := slices.Clone(input)
+			s.sort(a, byKey)
+			// stability means the whole encoded value ends up ascending
+			if !IsSorted(a) {
+				t.Errorf("%s Not Stable for n = %d", s.name, n)
+			}
+		}
+	}
+}
+
+// larger inputs in the shapes that hurt: random, sorted, reversed, all
+// equal, organ pipe; and a reversed comparator
+func Test3(t *testing.T) {
+	r := rand.New(rand.NewPCG(2, 2))
+	n := 5000
+	shapes := map[string]func(i int) int{
+		"random":    func(i int) int { return r.IntN(n) },
+		"sorted":    func(i int) int { return i },
+		"reversed":  func(i int) int { return n - i },
+		"equal":     func(i int) int { return 7 },
+		"organPipe": func(i int) int { return min(i, n-i) },
+		"fewValues": func(i int) int { return r.IntN(3) },
+	}
+	for shape, gen := range shapes {
+		input := make([]int, n)
+		for i := range input {
+			input[i] = gen(i)
+		}
+		for _, s := range sorts {
+			a := slices.Clone(input)
+			s.sort(a, cmp.Compare[int])
+			if !IsSorted(a) {
+				t.Errorf("%s Wrong on %s", s.name, shape)
+			}
+			s.sort(a, func(x int, y int) int { return cmp.Compare(y, x) })
+			if !IsSortedFunc(a, func(x int, y int) int { return cmp.Compare(y, x) }) {
+				t.Errorf("%s Wrong on %s with a reversed comparator", s.name, shape)
+			}
+		}
+	}
+}
+
+func Test4(t *testing.T) {
+	a := []string{"pear", "apple", "fig", "banana"}
+	Hybrid(a)
+	b := []float64{2.5, -1, 0, 3}
+	MergeBottomUp(b)
+	if !slices.Equal(a, []string{"apple", "banana", "fig", "pear"}) || !slices.Equal(b, []float64{-1, 0, 2.5, 3}) {
+		t.Error("Ordered Types Wrong")
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	for _, n := range []int{1e2, 1e4, 1e6} {
+		r := rand.New(rand.NewPCG(1, 2))
+		input := make([]int, n)
+		for i := range input {
+			input[i] = r.Int()
+		}
+		a := make([]int, n)
+		for _, s := range sorts {
+			if s.name == "Insertion" && n > 1e4 {
+				continue
+			}
+			b.Run(s.name+"/n="+strconv.Itoa(n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(a, input)
+					s.sort(a, cmp.Compare[int])
+				}
+			})
+		}
+		b.Run("slices.Sort/n="+strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(a, input)
+				slices.Sort(a)
+			}
+		})
+	}
+}
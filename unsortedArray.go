@@ -1,87 +1,299 @@
 package main
 
-import "fmt"
-
-/**
-Delete(key int)
-Min() int
-Max() int
-Floor(k int) int
-Ceiling(k int) int
-Select(k int)             // Return the key in the symbol table whose rank is k
-Rank(key int)             // Return the number of keys in the symbol table strictly less than `key`
-Keys()                    // Returns all keys in the symbol table as an Iterable
-RangeKeys(lo int, hi int) // Returns all keys in the symbol table in the given range.
-RangeSize(lo int, hi int) // Returns the number of keys in the symbol table in the given range.
-**/
-
-type Node struct {
-	key int
-	val int
-}
-
-type SortedArray struct {
+import (
+	"algo/utils"
+	"errors"
+	"slices"
+	"strconv"
+)
+
+// A symbol table implemented with an unordered array and sequential search.
+// Every operation except Put on a new key scans the whole array.
+// https://algs4.cs.princeton.edu/code/edu/princeton/cs/algs4/SequentialSearchST.java.html
+
+type UnsortedArray struct {
 	array []Node
+	// orders the keys, nil for ascending order
+	comparator utils.Comparator
 }
 
-func NewSortedArray() *SortedArray {
-	arrObj := new(SortedArray)
+func NewUnsortedArray() *UnsortedArray {
+	arrObj := new(UnsortedArray)
 	return arrObj
 }
 
-// If the target is found,
-//   then the index ( = how many keys < k) is returned.
-// If the target is not found, then the index to be
-//   inserted of k ( =  ( = how many keys < k)) is returned.
-// https://www.zhihu.com/question/27161493
-func (self *SortedArray) BinarySearch(key int) int {
-	left := 0
-	right := len(self.array) - 1
-	for left <= right {
-		mid := (left + right) / 2
-		if self.array[mid].key == key {
-			return mid
-		} else if self.array[mid].key < key {
-			left = mid + 1
-		} else {
-			right = mid - 1
+// Returns an empty array ordered by compare
+func NewUnsortedArrayWithComparator(compare utils.Comparator) *UnsortedArray {
+	return &UnsortedArray{comparator: compare}
+}
+
+// Returns the keys of the nodes, sorted by the comparator
+func (self *UnsortedArray) sortedKeys(nodes []Node) []int {
+	var res []int
+	for _, node := range nodes {
+		res = append(res, node.key)
+	}
+	slices.SortFunc(res, self.comparator.Compare)
+	return res
+}
+
+// Reports whether key lies in [lo, hi]
+func (self *UnsortedArray) inRange(key int, lo int, hi int) bool {
+	return self.comparator.Compare(lo, key) <= 0 && self.comparator.Compare(key, hi) <= 0
+}
+
+// Returns the index of key, or -1 if the key is not in the array
+func (self *UnsortedArray) SequentialSearch(key int) int {
+	for idx, node := range self.array {
+		if node.key == key {
+			return idx
 		}
 	}
-	return left
+	return -1
+}
+
+// Returns the number of key-value pairs in this symbol table.
+func (self *UnsortedArray) Size() int {
+	return len(self.array)
+}
+
+func (self *UnsortedArray) Contains(key int) bool {
+	return self.SequentialSearch(key) != -1
+}
+
+// Get value by key, return 0 if not exist
+func (self *UnsortedArray) Get(key int) int {
+	idx := self.SequentialSearch(key)
+	if idx == -1 {
+		return 0
+	}
+	return self.array[idx].val
+}
+
+func (self *UnsortedArray) Put(key int, val int) {
+	idx := self.SequentialSearch(key)
+	if idx != -1 {
+		self.array[idx].val = val
+		return
+	}
+	self.array = append(self.array, Node{key, val})
 }
 
-func (self *SortedArray) Contains(key int) bool {
-	idx := self.BinarySearch(key)
-	return idx < len(self.array) && self.array[idx].key == key
+// Removes the key and associated value from the symbol table, if present.
+// The last node takes the place of the removed one.
+func (self *UnsortedArray) Delete(key int) {
+	idx := self.SequentialSearch(key)
+	if idx == -1 {
+		return
+	}
+	last := len(self.array) - 1
+	self.array[idx] = self.array[last]
+	self.array = self.array[:last]
 }
 
-func (self *SortedArray) Put(key int, val int) {
-	idx := self.BinarySearch(key)
-	// The append built-in function appends elements to the end of a slice.
-	//    If it has sufficient capacity, the destination is resliced to accommodate the new elements.
-	//    If it does not, a new underlying array will be allocated.
-	// The following expression is WRONG, since the append(self.array[:idx], Node{key, val}) \
-	//    will modify the underlying array
-	// self.array = append(append(self.array[:idx], Node{key, val}), self.array[idx:]...)
-	self.array = append(self.array[:idx], append([]Node{Node{key, val}}, self.array[idx:]...)...)
+// Returns the index of the smallest key, -1 if the array is empty
+func (self *UnsortedArray) minIndex() int {
+	res := -1
+	for idx, node := range self.array {
+		if res == -1 || self.comparator.Compare(node.key, self.array[res].key) < 0 {
+			res = idx
+		}
+	}
+	return res
 }
 
-func (self *SortedArray) Print() {
-	fmt.Printf("\n")
+// Returns the index of the largest key, -1 if the array is empty
+func (self *UnsortedArray) maxIndex() int {
+	res := -1
+	for idx, node := range self.array {
+		if res == -1 || self.comparator.Compare(node.key, self.array[res].key) > 0 {
+			res = idx
+		}
+	}
+	return res
+}
+
+// Removes the smallest key and associated value from the symbol table.
+func (self *UnsortedArray) DeleteMin() {
+	if len(self.array) == 0 {
+		return
+	}
+	self.Delete(self.array[self.minIndex()].key)
+}
+
+// Removes the largest key and associated value from the symbol table
+func (self *UnsortedArray) DeleteMax() {
+	if len(self.array) == 0 {
+		return
+	}
+	self.Delete(self.array[self.maxIndex()].key)
+}
+
+// Removes the key and returns its value; false if the key was not there
+func (self *UnsortedArray) Remove(key int) (val int, ok bool) {
+	idx := self.SequentialSearch(key)
+	if idx == -1 {
+		return 0, false
+	}
+	val = self.array[idx].val
+	self.Delete(key)
+	return val, true
+}
+
+// Removes the smallest key and returns it with its value; false if the array is empty
+func (self *UnsortedArray) PopMin() (key int, val int, ok bool) {
+	if len(self.array) == 0 {
+		return 0, 0, false
+	}
+	key, val = self.Min()
+	self.Delete(key)
+	return key, val, true
+}
+
+// Removes the largest key and returns it with its value; false if the array is empty
+func (self *UnsortedArray) PopMax() (key int, val int, ok bool) {
+	if len(self.array) == 0 {
+		return 0, 0, false
+	}
+	key, val = self.Max()
+	self.Delete(key)
+	return key, val, true
+}
+
+// Removes every key in [lo, hi] and returns how many there were, in one scan
+func (self *UnsortedArray) DeleteRange(lo int, hi int) int {
+	kept := self.array[:0]
 	for _, node := range self.array {
-		fmt.Printf("%v ", node.key)
-		// only printf can support placeholder
+		if !self.inRange(node.key, lo, hi) {
+			kept = append(kept, node)
+		}
 	}
+	removed := len(self.array) - len(kept)
+	self.array = kept
+	return removed
 }
 
-func main() {
-	arr := NewSortedArray()
-	arr.Put(10, 5)
-	arr.Print()
-	arr.Put(4, 7)
-	arr.Print()
-	arr.Put(1, 4)
-	arr.Print()
-	arr.Put(3, 9)
-	arr.Print()
+func (self *UnsortedArray) Min() (key int, val int) {
+	node := self.array[self.minIndex()]
+	return node.key, node.val
+}
+
+func (self *UnsortedArray) Max() (key int, val int) {
+	node := self.array[self.maxIndex()]
+	return node.key, node.val
+}
+
+// Returns the node with the largest key in the symbol table less than or equal to key.
+func (self *UnsortedArray) Floor(key int) *Node {
+	var res *Node
+	for _, node := range self.array {
+		if self.comparator.Compare(node.key, key) <= 0 && (res == nil || self.comparator.Compare(node.key, res.key) > 0) {
+			found := node
+			res = &found
+		}
+	}
+	return res
+}
+
+// Returns the node with the smallest key in the symbol table greater than or equal to key.
+func (self *UnsortedArray) Ceiling(key int) *Node {
+	var res *Node
+	for _, node := range self.array {
+		if self.comparator.Compare(node.key, key) >= 0 && (res == nil || self.comparator.Compare(node.key, res.key) < 0) {
+			found := node
+			res = &found
+		}
+	}
+	return res
+}
+
+// Return the node in the symbol table whose rank is k
+func (self *UnsortedArray) Select(k int) *Node {
+	for _, node := range self.array {
+		if self.Rank(node.key) == k {
+			found := node
+			return &found
+		}
+	}
+	return nil
+}
+
+// Return the number of keys in the symbol table strictly less than `key`
+func (self *UnsortedArray) Rank(key int) int {
+	res := 0
+	for _, node := range self.array {
+		if self.comparator.Compare(node.key, key) < 0 {
+			res++
+		}
+	}
+	return res
+}
+
+// Returns all keys in the symbol table in order
+func (self *UnsortedArray) Keys() []int {
+	return self.sortedKeys(self.array)
+}
+
+// Returns all keys in the symbol table in the given range, in order
+func (self *UnsortedArray) RangeKeys(lo int, hi int) []int {
+	var nodes []Node
+	for _, node := range self.array {
+		if self.inRange(node.key, lo, hi) {
+			nodes = append(nodes, node)
+		}
+	}
+	return self.sortedKeys(nodes)
+}
+
+// Returns the number of keys in the symbol table in the given range.
+func (self *UnsortedArray) RangeSize(lo int, hi int) int {
+	res := 0
+	for _, node := range self.array {
+		if self.inRange(node.key, lo, hi) {
+			res++
+		}
+	}
+	return res
+}
+
+// Returns a copy of the array with its own storage and the same comparator
+func (self *UnsortedArray) Clone() *UnsortedArray {
+	return &UnsortedArray{array: slices.Clone(self.array), comparator: self.comparator}
+}
+
+// Reports whether the two arrays hold the same key-value pairs, in whatever
+// order. Every pair is searched for in the other array, O(n^2).
+func (self *UnsortedArray) Equal(other *UnsortedArray) bool {
+	if len(self.array) != len(other.array) {
+		return false
+	}
+	for _, node := range self.array {
+		idx := other.SequentialSearch(node.key)
+		if idx == -1 || other.array[idx].val != node.val {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether the two arrays hold the same key-value pairs in the same slots
+func (self *UnsortedArray) EqualShape(other *UnsortedArray) bool {
+	return slices.Equal(self.array, other.array)
+}
+
+// Removes every key; the comparator stays
+func (self *UnsortedArray) Clear() {
+	self.array = nil
+}
+
+// Checks that no key is stored twice
+func (self *UnsortedArray) check() error {
+	seen := make(map[int]bool)
+	for _, node := range self.array {
+		if seen[node.key] {
+			return errors.New("duplicate key " + strconv.Itoa(node.key))
+		}
+		seen[node.key] = true
+	}
+	return nil
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Put on an existing key replaces its value in place
+func TestUnsortedArrayPut(t *testing.T) {
+	st := NewUnsortedArray()
+	for _, k := range []int{5, 1, 3, 5, 1} {
+		st.Put(k, k*10)
+	}
+	st.Put(3, 7)
+	if st.Size() != 3 || !reflect.DeepEqual(st.Keys(), []int{1, 3, 5}) || st.Get(3) != 7 || st.Get(4) != 0 {
+		t.Error("Put Wrong", st.Keys())
+	}
+}
+
+func TestUnsortedArrayOrdered(t *testing.T) {
+	st := NewUnsortedArray()
+	for _, k := range []int{9, 3, 5, 1, 7} {
+		st.Put(k, k*10)
+	}
+	if n := st.Floor(4); n == nil || n.key != 3 || st.Floor(0) != nil {
+		t.Error("Floor Wrong")
+	}
+	if n := st.Ceiling(4); n == nil || n.key != 5 || st.Ceiling(10) != nil {
+		t.Error("Ceiling Wrong")
+	}
+	if n := st.Select(1); n == nil || n.key != 3 || st.Select(5) != nil || st.Rank(4) != 2 {
+		t.Error("Select/Rank Wrong")
+	}
+	if !reflect.DeepEqual(st.RangeKeys(2, 7), []int{3, 5, 7}) || st.RangeSize(2, 7) != 3 || st.RangeSize(7, 2) != 0 {
+		t.Error("Range Wrong")
+	}
+	st.Delete(5)
+	st.DeleteMin()
+	st.DeleteMax()
+	if k, v := st.Min(); k != 3 || v != 30 || st.Contains(5) || st.Size() != 2 {
+		t.Error("Delete Wrong")
+	}
+	if k, _ := st.Max(); k != 7 {
+		t.Error("Max Wrong")
+	}
+}
@@ -0,0 +1,16 @@
+package utils
+
+import "cmp"
+
+// Comparator orders two keys the way cmp.Compare does, returning a negative
+// number, zero or a positive number. The nil Comparator is ascending order,
+// so the zero value of an ordered table needs none.
+type Comparator func(a int, b int) int
+
+// Compares a and b with c, or with cmp.Compare if c is nil
+func (c Comparator) Compare(a int, b int) int {
+	if c == nil {
+		return cmp.Compare(a, b)
+	}
+	return c(a, b)
+}
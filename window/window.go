@@ -0,0 +1,234 @@
+// Order statistics over the last n values of a stream: SlidingMedian,
+// SlidingQuantile and SlidingMinMax. The median and quantile windows keep
+// their values in an AVLTree.AVLMultiset and read the answer with Select,
+// so each Push is O(log n); SlidingMinMax keeps two monotonic deques and
+// pushes in amortized O(1). Values are ints or float64s; floats are stored
+// as ints with the same order, and NaN is not allowed.
+// https://en.wikipedia.org/wiki/Order_statistic_tree
+package window
+
+import (
+	"math"
+
+	"algo/searching/AVLTree"
+)
+
+// The value types a window can hold
+type Value interface {
+	int | float64
+}
+
+// Maps a float64 to an int with the same order: non-negative floats already
+// order like their bits, negative ones order backwards, so flip all but the
+// sign bit of those
+func encodeFloat(f float64) int {
+	if math.IsNaN(f) {
+		panic("window: NaN value")
+	}
+	b := int64(math.Float64bits(f))
+	if b < 0 {
+		b ^= math.MaxInt64
+	}
+	return int(b)
+}
+
+func decodeFloat(k int) float64 {
+	b := int64(k)
+	if b < 0 {
+		b ^= math.MaxInt64
+	}
+	return math.Float64frombits(uint64(b))
+}
+
+// Returns the int a value is stored as in the multiset
+func encode[T Value](v T) int {
+	switch x := any(v).(type) {
+	case float64:
+		return encodeFloat(x)
+	default:
+		return x.(int)
+	}
+}
+
+func decode[T Value](k int) T {
+	var zero T
+	if _, ok := any(zero).(float64); ok {
+		return any(decodeFloat(k)).(T)
+	}
+	return any(k).(T)
+}
+
+// The last n values pushed: a ring buffer of them in arrival order plus
+// a multiset of their encodings in value order
+type window[T Value] struct {
+	n     int
+	ring  []T // the values, the oldest at head once the ring is full
+	head  int
+	order AVLTree.AVLMultiset
+}
+
+func newWindow[T Value](n int) window[T] {
+	if n < 1 {
+		panic("window: size must be positive")
+	}
+	return window[T]{n: n, ring: make([]T, 0, n)}
+}
+
+// Adds v, dropping the oldest value if the window is full
+func (w *window[T]) push(v T) {
+	if len(w.ring) < w.n {
+		w.ring = append(w.ring, v)
+	} else {
+		w.order.RemoveOne(encode(w.ring[w.head]))
+		w.ring[w.head] = v
+		w.head = (w.head + 1) % w.n
+	}
+	w.order.Add(encode(v))
+}
+
+// Returns the value at index k of the window in ascending order
+func (w *window[T]) selectValue(k int) T {
+	key, _ := w.order.Select(k)
+	return decode[T](key)
+}
+
+// The p-quantile of the last n values
+type SlidingQuantile[T Value] struct {
+	w window[T]
+	p float64
+}
+
+// Returns an empty window over the last n values reporting their
+// p-quantile, for p in [0, 1]
+func NewSlidingQuantile[T Value](n int, p float64) *SlidingQuantile[T] {
+	if !(p >= 0 && p <= 1) {
+		panic("window: quantile out of [0, 1]")
+	}
+	return &SlidingQuantile[T]{w: newWindow[T](n), p: p}
+}
+
+// Returns the number of values in the window, at most n
+func (s *SlidingQuantile[T]) Len() int {
+	return len(s.w.ring)
+}
+
+// Adds v, dropping the oldest value if the window is full
+func (s *SlidingQuantile[T]) Push(v T) {
+	s.w.push(v)
+}
+
+// Returns the value at index floor(p*(Len()-1)) of the window in ascending
+// order, so 0 gives the minimum and 1 the maximum; false if the window is empty
+func (s *SlidingQuantile[T]) Quantile() (T, bool) {
+	if s.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.w.selectValue(int(s.p * float64(s.Len()-1))), true
+}
+
+// The median of the last n values
+type SlidingMedian[T Value] struct {
+	w window[T]
+}
+
+// Returns an empty window over the last n values reporting their median
+func NewSlidingMedian[T Value](n int) *SlidingMedian[T] {
+	return &SlidingMedian[T]{w: newWindow[T](n)}
+}
+
+// Returns the number of values in the window, at most n
+func (s *SlidingMedian[T]) Len() int {
+	return len(s.w.ring)
+}
+
+// Adds v, dropping the oldest value if the window is full
+func (s *SlidingMedian[T]) Push(v T) {
+	s.w.push(v)
+}
+
+// Returns the middle value of the window, or the mean of the two middle
+// values when Len() is even; false if the window is empty
+func (s *SlidingMedian[T]) Median() (float64, bool) {
+	n := s.Len()
+	if n == 0 {
+		return 0, false
+	}
+	hi := float64(s.w.selectValue(n / 2))
+	if n%2 == 1 {
+		return hi, true
+	}
+	lo := float64(s.w.selectValue(n/2 - 1))
+	return lo + (hi-lo)/2, true
+}
+
+// A value with its position in the stream
+type indexed[T Value] struct {
+	i int
+	v T
+}
+
+// The minimum and maximum of the last n values
+type SlidingMinMax[T Value] struct {
+	n     int
+	count int          // values pushed so far
+	mins  []indexed[T] // candidates for the minimum, values strictly increasing
+	maxs  []indexed[T] // candidates for the maximum, values strictly decreasing
+}
+
+// Returns an empty window over the last n values reporting their minimum and maximum
+func NewSlidingMinMax[T Value](n int) *SlidingMinMax[T] {
+	if n < 1 {
+		panic("window: size must be positive")
+	}
+	return &SlidingMinMax[T]{n: n}
+}
+
+// Returns the number of values in the window, at most n
+func (s *SlidingMinMax[T]) Len() int {
+	return min(s.count, s.n)
+}
+
+// Adds v, dropping the oldest value if the window is full. A value is only
+// a candidate while no newer value beats it, so each value enters and
+// leaves each deque once.
+func (s *SlidingMinMax[T]) Push(v T) {
+	if v != v {
+		panic("window: NaN value")
+	}
+	i := s.count
+	s.count++
+	for len(s.mins) > 0 && s.mins[len(s.mins)-1].v >= v {
+		s.mins = s.mins[:len(s.mins)-1]
+	}
+	s.mins = append(s.mins, indexed[T]{i, v})
+	for len(s.maxs) > 0 && s.maxs[len(s.maxs)-1].v <= v {
+		s.maxs = s.maxs[:len(s.maxs)-1]
+	}
+	s.maxs = append(s.maxs, indexed[T]{i, v})
+	// drop the candidates that slid out of the window
+	if s.mins[0].i <= i-s.n {
+		s.mins = s.mins[1:]
+	}
+	if s.maxs[0].i <= i-s.n {
+		s.maxs = s.maxs[1:]
+	}
+}
+
+// Returns the smallest value in the window, false if it is empty
+func (s *SlidingMinMax[T]) Min() (T, bool) {
+	if s.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.mins[0].v, true
+}
+
+// Returns the largest value in the window, false if it is empty
+func (s *SlidingMinMax[T]) Max() (T, bool) {
+	if s.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.maxs[0].v, true
+}
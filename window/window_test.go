@@ -0,0 +1,99 @@
+package window
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// floats keep their order through the encoding, and come back unchanged
+func Test1(t *testing.T) {
+	floats := []float64{math.Inf(-1), -math.MaxFloat64, -1.5, -math.SmallestNonzeroFloat64, math.Copysign(0, -1), 0,
+		math.SmallestNonzeroFloat64, 1, 1.5, math.MaxFloat64, math.Inf(1)}
+	for i, f := range floats {
+		if got := decodeFloat(encodeFloat(f)); math.Float64bits(got) != math.Float64bits(f) {
+			t.Error("Decode Wrong", f, got)
+		}
+		if i > 0 && encodeFloat(floats[i-1]) >= encodeFloat(f) {
+			t.Error("Order Wrong", floats[i-1], f)
+		}
+	}
+}
+
+func Test2(t *testing.T) {
+	m := NewSlidingMedian[int](3)
+	if _, ok := m.Median(); ok {
+		t.Error("Empty Wrong")
+	}
+	var medians []float64
+	for _, v := range []int{5, 1, 4, 2, 8, 8} {
+		m.Push(v)
+		med, _ := m.Median()
+		medians = append(medians, med)
+	}
+	if !slices.Equal(medians, []float64{5, 3, 4, 2, 4, 8}) || m.Len() != 3 {
+		t.Error("Median Wrong", medians)
+	}
+	q := NewSlidingQuantile[float64](4, 1)
+	for _, v := range []float64{-2.5, 7, 0.5, -1, -3} {
+		q.Push(v)
+	}
+	if v, _ := q.Quantile(); v != 7 {
+		t.Error("Quantile Wrong", v)
+	}
+	mm := NewSlidingMinMax[float64](2)
+	for _, v := range []float64{3, -1, 2} {
+		mm.Push(v)
+	}
+	if lo, _ := mm.Min(); lo != -1 {
+		t.Error("Min Wrong")
+	}
+	if hi, _ := mm.Max(); hi != 2 {
+		t.Error("Max Wrong")
+	}
+}
+
+// every window over random streams against sorting the window
+func Test3(t *testing.T) {
+	r := rand.New(rand.NewPCG(46, 46))
+	for _, n := range []int{1, 2, 5, 16} {
+		for _, p := range []float64{0, 0.25, 0.5, 0.95, 1} {
+			med := NewSlidingMedian[float64](n)
+			quant := NewSlidingQuantile[float64](n, p)
+			intQuant := NewSlidingQuantile[int](n, p)
+			mm := NewSlidingMinMax[float64](n)
+			var stream []float64
+			for i := 0; i < 300; i++ {
+				v := float64(r.IntN(21)-10) / 4
+				stream = append(stream, v)
+				med.Push(v)
+				quant.Push(v)
+				intQuant.Push(int(v * 4))
+				mm.Push(v)
+				last := slices.Clone(stream[max(0, len(stream)-n):])
+				slices.Sort(last)
+				k := len(last)
+				want := last[k/2]
+				if k%2 == 0 {
+					want = (last[k/2-1] + last[k/2]) / 2
+				}
+				if got, _ := med.Median(); got != want {
+					t.Fatal("Median Wrong", n, i, got, want)
+				}
+				idx := int(p * float64(k-1))
+				if got, _ := quant.Quantile(); got != last[idx] {
+					t.Fatal("Quantile Wrong", n, p, i)
+				}
+				if got, _ := intQuant.Quantile(); got != int(last[idx]*4) {
+					t.Fatal("Int Quantile Wrong", n, p, i)
+				}
+				lo, _ := mm.Min()
+				hi, _ := mm.Max()
+				if lo != last[0] || hi != last[k-1] || mm.Len() != k {
+					t.Fatal("MinMax Wrong", n, i)
+				}
+			}
+		}
+	}
+}